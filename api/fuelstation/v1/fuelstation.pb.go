@@ -0,0 +1,2044 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        (unknown)
+// source: fuelstation.proto
+
+package fuelstationv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type RefuelOperation struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	AmountPaidKop    int64                  `protobuf:"varint,2,opt,name=amount_paid_kop,json=amountPaidKop,proto3" json:"amount_paid_kop,omitempty"`
+	CalculatedVolume int64                  `protobuf:"varint,3,opt,name=calculated_volume,json=calculatedVolume,proto3" json:"calculated_volume,omitempty"`
+	PricePerLiterKop int64                  `protobuf:"varint,4,opt,name=price_per_liter_kop,json=pricePerLiterKop,proto3" json:"price_per_liter_kop,omitempty"`
+	CounterBefore    int64                  `protobuf:"varint,5,opt,name=counter_before,json=counterBefore,proto3" json:"counter_before,omitempty"`
+	CounterAfter     int64                  `protobuf:"varint,6,opt,name=counter_after,json=counterAfter,proto3" json:"counter_after,omitempty"`
+	Status           string                 `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
+	CreatedAt        *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	CancelledAt      *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=cancelled_at,json=cancelledAt,proto3" json:"cancelled_at,omitempty"`
+	CancelReason     *string                `protobuf:"bytes,10,opt,name=cancel_reason,json=cancelReason,proto3,oneof" json:"cancel_reason,omitempty"`
+	Grade            string                 `protobuf:"bytes,11,opt,name=grade,proto3" json:"grade,omitempty"`
+	DeviceId         string                 `protobuf:"bytes,12,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	IdempotencyKey   *string                `protobuf:"bytes,13,opt,name=idempotency_key,json=idempotencyKey,proto3,oneof" json:"idempotency_key,omitempty"`
+	DispensedVolume  *int64                 `protobuf:"varint,14,opt,name=dispensed_volume,json=dispensedVolume,proto3,oneof" json:"dispensed_volume,omitempty"`
+	RefundDueKop     int64                  `protobuf:"varint,15,opt,name=refund_due_kop,json=refundDueKop,proto3" json:"refund_due_kop,omitempty"`
+	RefundedKop      int64                  `protobuf:"varint,16,opt,name=refunded_kop,json=refundedKop,proto3" json:"refunded_kop,omitempty"`
+	Mode             string                 `protobuf:"bytes,17,opt,name=mode,proto3" json:"mode,omitempty"`
+	PaidKop          int64                  `protobuf:"varint,18,opt,name=paid_kop,json=paidKop,proto3" json:"paid_kop,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *RefuelOperation) Reset() {
+	*x = RefuelOperation{}
+	mi := &file_fuelstation_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefuelOperation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefuelOperation) ProtoMessage() {}
+
+func (x *RefuelOperation) ProtoReflect() protoreflect.Message {
+	mi := &file_fuelstation_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefuelOperation.ProtoReflect.Descriptor instead.
+func (*RefuelOperation) Descriptor() ([]byte, []int) {
+	return file_fuelstation_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RefuelOperation) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *RefuelOperation) GetAmountPaidKop() int64 {
+	if x != nil {
+		return x.AmountPaidKop
+	}
+	return 0
+}
+
+func (x *RefuelOperation) GetCalculatedVolume() int64 {
+	if x != nil {
+		return x.CalculatedVolume
+	}
+	return 0
+}
+
+func (x *RefuelOperation) GetPricePerLiterKop() int64 {
+	if x != nil {
+		return x.PricePerLiterKop
+	}
+	return 0
+}
+
+func (x *RefuelOperation) GetCounterBefore() int64 {
+	if x != nil {
+		return x.CounterBefore
+	}
+	return 0
+}
+
+func (x *RefuelOperation) GetCounterAfter() int64 {
+	if x != nil {
+		return x.CounterAfter
+	}
+	return 0
+}
+
+func (x *RefuelOperation) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *RefuelOperation) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *RefuelOperation) GetCancelledAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CancelledAt
+	}
+	return nil
+}
+
+func (x *RefuelOperation) GetCancelReason() string {
+	if x != nil && x.CancelReason != nil {
+		return *x.CancelReason
+	}
+	return ""
+}
+
+func (x *RefuelOperation) GetGrade() string {
+	if x != nil {
+		return x.Grade
+	}
+	return ""
+}
+
+func (x *RefuelOperation) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *RefuelOperation) GetIdempotencyKey() string {
+	if x != nil && x.IdempotencyKey != nil {
+		return *x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *RefuelOperation) GetDispensedVolume() int64 {
+	if x != nil && x.DispensedVolume != nil {
+		return *x.DispensedVolume
+	}
+	return 0
+}
+
+func (x *RefuelOperation) GetRefundDueKop() int64 {
+	if x != nil {
+		return x.RefundDueKop
+	}
+	return 0
+}
+
+func (x *RefuelOperation) GetRefundedKop() int64 {
+	if x != nil {
+		return x.RefundedKop
+	}
+	return 0
+}
+
+func (x *RefuelOperation) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *RefuelOperation) GetPaidKop() int64 {
+	if x != nil {
+		return x.PaidKop
+	}
+	return 0
+}
+
+type CreateRefuelRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	AmountPaidKop  int64                  `protobuf:"varint,1,opt,name=amount_paid_kop,json=amountPaidKop,proto3" json:"amount_paid_kop,omitempty"`
+	CounterBefore  int64                  `protobuf:"varint,2,opt,name=counter_before,json=counterBefore,proto3" json:"counter_before,omitempty"`
+	Grade          string                 `protobuf:"bytes,3,opt,name=grade,proto3" json:"grade,omitempty"`
+	DeviceId       string                 `protobuf:"bytes,4,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	IdempotencyKey string                 `protobuf:"bytes,5,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	Mode           string                 `protobuf:"bytes,6,opt,name=mode,proto3" json:"mode,omitempty"`
+	Volume         int64                  `protobuf:"varint,7,opt,name=volume,proto3" json:"volume,omitempty"`
+	Payments       []*PaymentLine         `protobuf:"bytes,8,rep,name=payments,proto3" json:"payments,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CreateRefuelRequest) Reset() {
+	*x = CreateRefuelRequest{}
+	mi := &file_fuelstation_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRefuelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRefuelRequest) ProtoMessage() {}
+
+func (x *CreateRefuelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fuelstation_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRefuelRequest.ProtoReflect.Descriptor instead.
+func (*CreateRefuelRequest) Descriptor() ([]byte, []int) {
+	return file_fuelstation_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateRefuelRequest) GetAmountPaidKop() int64 {
+	if x != nil {
+		return x.AmountPaidKop
+	}
+	return 0
+}
+
+func (x *CreateRefuelRequest) GetCounterBefore() int64 {
+	if x != nil {
+		return x.CounterBefore
+	}
+	return 0
+}
+
+func (x *CreateRefuelRequest) GetGrade() string {
+	if x != nil {
+		return x.Grade
+	}
+	return ""
+}
+
+func (x *CreateRefuelRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *CreateRefuelRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *CreateRefuelRequest) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *CreateRefuelRequest) GetVolume() int64 {
+	if x != nil {
+		return x.Volume
+	}
+	return 0
+}
+
+func (x *CreateRefuelRequest) GetPayments() []*PaymentLine {
+	if x != nil {
+		return x.Payments
+	}
+	return nil
+}
+
+type PaymentLine struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Method        string                 `protobuf:"bytes,1,opt,name=method,proto3" json:"method,omitempty"`
+	AmountKop     int64                  `protobuf:"varint,2,opt,name=amount_kop,json=amountKop,proto3" json:"amount_kop,omitempty"`
+	ExternalRef   *string                `protobuf:"bytes,3,opt,name=external_ref,json=externalRef,proto3,oneof" json:"external_ref,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PaymentLine) Reset() {
+	*x = PaymentLine{}
+	mi := &file_fuelstation_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PaymentLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaymentLine) ProtoMessage() {}
+
+func (x *PaymentLine) ProtoReflect() protoreflect.Message {
+	mi := &file_fuelstation_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PaymentLine.ProtoReflect.Descriptor instead.
+func (*PaymentLine) Descriptor() ([]byte, []int) {
+	return file_fuelstation_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PaymentLine) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *PaymentLine) GetAmountKop() int64 {
+	if x != nil {
+		return x.AmountKop
+	}
+	return 0
+}
+
+func (x *PaymentLine) GetExternalRef() string {
+	if x != nil && x.ExternalRef != nil {
+		return *x.ExternalRef
+	}
+	return ""
+}
+
+type AddPaymentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OperationId   string                 `protobuf:"bytes,1,opt,name=operation_id,json=operationId,proto3" json:"operation_id,omitempty"`
+	Payment       *PaymentLine           `protobuf:"bytes,2,opt,name=payment,proto3" json:"payment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddPaymentRequest) Reset() {
+	*x = AddPaymentRequest{}
+	mi := &file_fuelstation_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddPaymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddPaymentRequest) ProtoMessage() {}
+
+func (x *AddPaymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fuelstation_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddPaymentRequest.ProtoReflect.Descriptor instead.
+func (*AddPaymentRequest) Descriptor() ([]byte, []int) {
+	return file_fuelstation_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *AddPaymentRequest) GetOperationId() string {
+	if x != nil {
+		return x.OperationId
+	}
+	return ""
+}
+
+func (x *AddPaymentRequest) GetPayment() *PaymentLine {
+	if x != nil {
+		return x.Payment
+	}
+	return nil
+}
+
+type Payment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	OperationId   int64                  `protobuf:"varint,2,opt,name=operation_id,json=operationId,proto3" json:"operation_id,omitempty"`
+	Method        string                 `protobuf:"bytes,3,opt,name=method,proto3" json:"method,omitempty"`
+	AmountKop     int64                  `protobuf:"varint,4,opt,name=amount_kop,json=amountKop,proto3" json:"amount_kop,omitempty"`
+	ExternalRef   *string                `protobuf:"bytes,5,opt,name=external_ref,json=externalRef,proto3,oneof" json:"external_ref,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Payment) Reset() {
+	*x = Payment{}
+	mi := &file_fuelstation_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Payment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Payment) ProtoMessage() {}
+
+func (x *Payment) ProtoReflect() protoreflect.Message {
+	mi := &file_fuelstation_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Payment.ProtoReflect.Descriptor instead.
+func (*Payment) Descriptor() ([]byte, []int) {
+	return file_fuelstation_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Payment) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Payment) GetOperationId() int64 {
+	if x != nil {
+		return x.OperationId
+	}
+	return 0
+}
+
+func (x *Payment) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *Payment) GetAmountKop() int64 {
+	if x != nil {
+		return x.AmountKop
+	}
+	return 0
+}
+
+func (x *Payment) GetExternalRef() string {
+	if x != nil && x.ExternalRef != nil {
+		return *x.ExternalRef
+	}
+	return ""
+}
+
+func (x *Payment) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type ConfirmRefuelRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CounterAfter  *int64                 `protobuf:"varint,2,opt,name=counter_after,json=counterAfter,proto3,oneof" json:"counter_after,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmRefuelRequest) Reset() {
+	*x = ConfirmRefuelRequest{}
+	mi := &file_fuelstation_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmRefuelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmRefuelRequest) ProtoMessage() {}
+
+func (x *ConfirmRefuelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fuelstation_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmRefuelRequest.ProtoReflect.Descriptor instead.
+func (*ConfirmRefuelRequest) Descriptor() ([]byte, []int) {
+	return file_fuelstation_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ConfirmRefuelRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ConfirmRefuelRequest) GetCounterAfter() int64 {
+	if x != nil && x.CounterAfter != nil {
+		return *x.CounterAfter
+	}
+	return 0
+}
+
+type CancelRefuelRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelRefuelRequest) Reset() {
+	*x = CancelRefuelRequest{}
+	mi := &file_fuelstation_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelRefuelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelRefuelRequest) ProtoMessage() {}
+
+func (x *CancelRefuelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fuelstation_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelRefuelRequest.ProtoReflect.Descriptor instead.
+func (*CancelRefuelRequest) Descriptor() ([]byte, []int) {
+	return file_fuelstation_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CancelRefuelRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CancelRefuelRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type GetRefuelRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRefuelRequest) Reset() {
+	*x = GetRefuelRequest{}
+	mi := &file_fuelstation_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRefuelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRefuelRequest) ProtoMessage() {}
+
+func (x *GetRefuelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fuelstation_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRefuelRequest.ProtoReflect.Descriptor instead.
+func (*GetRefuelRequest) Descriptor() ([]byte, []int) {
+	return file_fuelstation_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetRefuelRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type StreamPendingOperationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Watch         bool                   `protobuf:"varint,1,opt,name=watch,proto3" json:"watch,omitempty"`
+	DeviceId      string                 `protobuf:"bytes,2,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamPendingOperationsRequest) Reset() {
+	*x = StreamPendingOperationsRequest{}
+	mi := &file_fuelstation_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamPendingOperationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamPendingOperationsRequest) ProtoMessage() {}
+
+func (x *StreamPendingOperationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fuelstation_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamPendingOperationsRequest.ProtoReflect.Descriptor instead.
+func (*StreamPendingOperationsRequest) Descriptor() ([]byte, []int) {
+	return file_fuelstation_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *StreamPendingOperationsRequest) GetWatch() bool {
+	if x != nil {
+		return x.Watch
+	}
+	return false
+}
+
+func (x *StreamPendingOperationsRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+type GetStatisticsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To            *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatisticsRequest) Reset() {
+	*x = GetStatisticsRequest{}
+	mi := &file_fuelstation_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatisticsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatisticsRequest) ProtoMessage() {}
+
+func (x *GetStatisticsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fuelstation_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatisticsRequest.ProtoReflect.Descriptor instead.
+func (*GetStatisticsRequest) Descriptor() ([]byte, []int) {
+	return file_fuelstation_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetStatisticsRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *GetStatisticsRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+type Statistics struct {
+	state                   protoimpl.MessageState     `protogen:"open.v1"`
+	TotalOperations         int64                      `protobuf:"varint,1,opt,name=total_operations,json=totalOperations,proto3" json:"total_operations,omitempty"`
+	TotalRevenueKop         int64                      `protobuf:"varint,2,opt,name=total_revenue_kop,json=totalRevenueKop,proto3" json:"total_revenue_kop,omitempty"`
+	TotalVolume             int64                      `protobuf:"varint,3,opt,name=total_volume,json=totalVolume,proto3" json:"total_volume,omitempty"`
+	AverageVolume           int64                      `protobuf:"varint,4,opt,name=average_volume,json=averageVolume,proto3" json:"average_volume,omitempty"`
+	AverageAmountKop        int64                      `protobuf:"varint,5,opt,name=average_amount_kop,json=averageAmountKop,proto3" json:"average_amount_kop,omitempty"`
+	ConfirmedCount          int64                      `protobuf:"varint,6,opt,name=confirmed_count,json=confirmedCount,proto3" json:"confirmed_count,omitempty"`
+	CancelledCount          int64                      `protobuf:"varint,7,opt,name=cancelled_count,json=cancelledCount,proto3" json:"cancelled_count,omitempty"`
+	PendingCount            int64                      `protobuf:"varint,8,opt,name=pending_count,json=pendingCount,proto3" json:"pending_count,omitempty"`
+	StartDate               *timestamppb.Timestamp     `protobuf:"bytes,9,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	EndDate                 *timestamppb.Timestamp     `protobuf:"bytes,10,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	ByGrade                 []*GradeStatistics         `protobuf:"bytes,11,rep,name=by_grade,json=byGrade,proto3" json:"by_grade,omitempty"`
+	PartiallyFulfilledCount int64                      `protobuf:"varint,12,opt,name=partially_fulfilled_count,json=partiallyFulfilledCount,proto3" json:"partially_fulfilled_count,omitempty"`
+	TotalRefundedKop        int64                      `protobuf:"varint,13,opt,name=total_refunded_kop,json=totalRefundedKop,proto3" json:"total_refunded_kop,omitempty"`
+	OutstandingRefundsKop   int64                      `protobuf:"varint,14,opt,name=outstanding_refunds_kop,json=outstandingRefundsKop,proto3" json:"outstanding_refunds_kop,omitempty"`
+	ByPaymentMethod         []*PaymentMethodStatistics `protobuf:"bytes,15,rep,name=by_payment_method,json=byPaymentMethod,proto3" json:"by_payment_method,omitempty"`
+	UnallocatedKop          int64                      `protobuf:"varint,16,opt,name=unallocated_kop,json=unallocatedKop,proto3" json:"unallocated_kop,omitempty"`
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *Statistics) Reset() {
+	*x = Statistics{}
+	mi := &file_fuelstation_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Statistics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Statistics) ProtoMessage() {}
+
+func (x *Statistics) ProtoReflect() protoreflect.Message {
+	mi := &file_fuelstation_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Statistics.ProtoReflect.Descriptor instead.
+func (*Statistics) Descriptor() ([]byte, []int) {
+	return file_fuelstation_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Statistics) GetTotalOperations() int64 {
+	if x != nil {
+		return x.TotalOperations
+	}
+	return 0
+}
+
+func (x *Statistics) GetTotalRevenueKop() int64 {
+	if x != nil {
+		return x.TotalRevenueKop
+	}
+	return 0
+}
+
+func (x *Statistics) GetTotalVolume() int64 {
+	if x != nil {
+		return x.TotalVolume
+	}
+	return 0
+}
+
+func (x *Statistics) GetAverageVolume() int64 {
+	if x != nil {
+		return x.AverageVolume
+	}
+	return 0
+}
+
+func (x *Statistics) GetAverageAmountKop() int64 {
+	if x != nil {
+		return x.AverageAmountKop
+	}
+	return 0
+}
+
+func (x *Statistics) GetConfirmedCount() int64 {
+	if x != nil {
+		return x.ConfirmedCount
+	}
+	return 0
+}
+
+func (x *Statistics) GetCancelledCount() int64 {
+	if x != nil {
+		return x.CancelledCount
+	}
+	return 0
+}
+
+func (x *Statistics) GetPendingCount() int64 {
+	if x != nil {
+		return x.PendingCount
+	}
+	return 0
+}
+
+func (x *Statistics) GetStartDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartDate
+	}
+	return nil
+}
+
+func (x *Statistics) GetEndDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndDate
+	}
+	return nil
+}
+
+func (x *Statistics) GetByGrade() []*GradeStatistics {
+	if x != nil {
+		return x.ByGrade
+	}
+	return nil
+}
+
+func (x *Statistics) GetPartiallyFulfilledCount() int64 {
+	if x != nil {
+		return x.PartiallyFulfilledCount
+	}
+	return 0
+}
+
+func (x *Statistics) GetTotalRefundedKop() int64 {
+	if x != nil {
+		return x.TotalRefundedKop
+	}
+	return 0
+}
+
+func (x *Statistics) GetOutstandingRefundsKop() int64 {
+	if x != nil {
+		return x.OutstandingRefundsKop
+	}
+	return 0
+}
+
+func (x *Statistics) GetByPaymentMethod() []*PaymentMethodStatistics {
+	if x != nil {
+		return x.ByPaymentMethod
+	}
+	return nil
+}
+
+func (x *Statistics) GetUnallocatedKop() int64 {
+	if x != nil {
+		return x.UnallocatedKop
+	}
+	return 0
+}
+
+type PaymentMethodStatistics struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Method        string                 `protobuf:"bytes,1,opt,name=method,proto3" json:"method,omitempty"`
+	PaymentCount  int64                  `protobuf:"varint,2,opt,name=payment_count,json=paymentCount,proto3" json:"payment_count,omitempty"`
+	PaidKop       int64                  `protobuf:"varint,3,opt,name=paid_kop,json=paidKop,proto3" json:"paid_kop,omitempty"`
+	RefundedKop   int64                  `protobuf:"varint,4,opt,name=refunded_kop,json=refundedKop,proto3" json:"refunded_kop,omitempty"`
+	NetKop        int64                  `protobuf:"varint,5,opt,name=net_kop,json=netKop,proto3" json:"net_kop,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PaymentMethodStatistics) Reset() {
+	*x = PaymentMethodStatistics{}
+	mi := &file_fuelstation_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PaymentMethodStatistics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaymentMethodStatistics) ProtoMessage() {}
+
+func (x *PaymentMethodStatistics) ProtoReflect() protoreflect.Message {
+	mi := &file_fuelstation_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PaymentMethodStatistics.ProtoReflect.Descriptor instead.
+func (*PaymentMethodStatistics) Descriptor() ([]byte, []int) {
+	return file_fuelstation_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *PaymentMethodStatistics) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *PaymentMethodStatistics) GetPaymentCount() int64 {
+	if x != nil {
+		return x.PaymentCount
+	}
+	return 0
+}
+
+func (x *PaymentMethodStatistics) GetPaidKop() int64 {
+	if x != nil {
+		return x.PaidKop
+	}
+	return 0
+}
+
+func (x *PaymentMethodStatistics) GetRefundedKop() int64 {
+	if x != nil {
+		return x.RefundedKop
+	}
+	return 0
+}
+
+func (x *PaymentMethodStatistics) GetNetKop() int64 {
+	if x != nil {
+		return x.NetKop
+	}
+	return 0
+}
+
+type GradeStatistics struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Grade           string                 `protobuf:"bytes,1,opt,name=grade,proto3" json:"grade,omitempty"`
+	ConfirmedCount  int64                  `protobuf:"varint,2,opt,name=confirmed_count,json=confirmedCount,proto3" json:"confirmed_count,omitempty"`
+	TotalRevenueKop int64                  `protobuf:"varint,3,opt,name=total_revenue_kop,json=totalRevenueKop,proto3" json:"total_revenue_kop,omitempty"`
+	TotalVolume     int64                  `protobuf:"varint,4,opt,name=total_volume,json=totalVolume,proto3" json:"total_volume,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GradeStatistics) Reset() {
+	*x = GradeStatistics{}
+	mi := &file_fuelstation_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GradeStatistics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GradeStatistics) ProtoMessage() {}
+
+func (x *GradeStatistics) ProtoReflect() protoreflect.Message {
+	mi := &file_fuelstation_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GradeStatistics.ProtoReflect.Descriptor instead.
+func (*GradeStatistics) Descriptor() ([]byte, []int) {
+	return file_fuelstation_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GradeStatistics) GetGrade() string {
+	if x != nil {
+		return x.Grade
+	}
+	return ""
+}
+
+func (x *GradeStatistics) GetConfirmedCount() int64 {
+	if x != nil {
+		return x.ConfirmedCount
+	}
+	return 0
+}
+
+func (x *GradeStatistics) GetTotalRevenueKop() int64 {
+	if x != nil {
+		return x.TotalRevenueKop
+	}
+	return 0
+}
+
+func (x *GradeStatistics) GetTotalVolume() int64 {
+	if x != nil {
+		return x.TotalVolume
+	}
+	return 0
+}
+
+type FuelGrade struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FuelGrade) Reset() {
+	*x = FuelGrade{}
+	mi := &file_fuelstation_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FuelGrade) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FuelGrade) ProtoMessage() {}
+
+func (x *FuelGrade) ProtoReflect() protoreflect.Message {
+	mi := &file_fuelstation_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FuelGrade.ProtoReflect.Descriptor instead.
+func (*FuelGrade) Descriptor() ([]byte, []int) {
+	return file_fuelstation_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *FuelGrade) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *FuelGrade) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type ListGradesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListGradesRequest) Reset() {
+	*x = ListGradesRequest{}
+	mi := &file_fuelstation_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListGradesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListGradesRequest) ProtoMessage() {}
+
+func (x *ListGradesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fuelstation_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListGradesRequest.ProtoReflect.Descriptor instead.
+func (*ListGradesRequest) Descriptor() ([]byte, []int) {
+	return file_fuelstation_proto_rawDescGZIP(), []int{14}
+}
+
+type ListGradesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Grades        []*FuelGrade           `protobuf:"bytes,1,rep,name=grades,proto3" json:"grades,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListGradesResponse) Reset() {
+	*x = ListGradesResponse{}
+	mi := &file_fuelstation_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListGradesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListGradesResponse) ProtoMessage() {}
+
+func (x *ListGradesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_fuelstation_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListGradesResponse.ProtoReflect.Descriptor instead.
+func (*ListGradesResponse) Descriptor() ([]byte, []int) {
+	return file_fuelstation_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListGradesResponse) GetGrades() []*FuelGrade {
+	if x != nil {
+		return x.Grades
+	}
+	return nil
+}
+
+type ListActivePricesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListActivePricesRequest) Reset() {
+	*x = ListActivePricesRequest{}
+	mi := &file_fuelstation_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListActivePricesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListActivePricesRequest) ProtoMessage() {}
+
+func (x *ListActivePricesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fuelstation_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListActivePricesRequest.ProtoReflect.Descriptor instead.
+func (*ListActivePricesRequest) Descriptor() ([]byte, []int) {
+	return file_fuelstation_proto_rawDescGZIP(), []int{16}
+}
+
+type ListActivePricesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Prices        []*FuelPrice           `protobuf:"bytes,1,rep,name=prices,proto3" json:"prices,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListActivePricesResponse) Reset() {
+	*x = ListActivePricesResponse{}
+	mi := &file_fuelstation_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListActivePricesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListActivePricesResponse) ProtoMessage() {}
+
+func (x *ListActivePricesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_fuelstation_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListActivePricesResponse.ProtoReflect.Descriptor instead.
+func (*ListActivePricesResponse) Descriptor() ([]byte, []int) {
+	return file_fuelstation_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListActivePricesResponse) GetPrices() []*FuelPrice {
+	if x != nil {
+		return x.Prices
+	}
+	return nil
+}
+
+type FuelPrice struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	PricePerLiterKop int64                  `protobuf:"varint,2,opt,name=price_per_liter_kop,json=pricePerLiterKop,proto3" json:"price_per_liter_kop,omitempty"`
+	CreatedAt        *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	IsActive         bool                   `protobuf:"varint,4,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	Grade            string                 `protobuf:"bytes,5,opt,name=grade,proto3" json:"grade,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *FuelPrice) Reset() {
+	*x = FuelPrice{}
+	mi := &file_fuelstation_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FuelPrice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FuelPrice) ProtoMessage() {}
+
+func (x *FuelPrice) ProtoReflect() protoreflect.Message {
+	mi := &file_fuelstation_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FuelPrice.ProtoReflect.Descriptor instead.
+func (*FuelPrice) Descriptor() ([]byte, []int) {
+	return file_fuelstation_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *FuelPrice) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *FuelPrice) GetPricePerLiterKop() int64 {
+	if x != nil {
+		return x.PricePerLiterKop
+	}
+	return 0
+}
+
+func (x *FuelPrice) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *FuelPrice) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+func (x *FuelPrice) GetGrade() string {
+	if x != nil {
+		return x.Grade
+	}
+	return ""
+}
+
+type GetPriceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Grade         string                 `protobuf:"bytes,1,opt,name=grade,proto3" json:"grade,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPriceRequest) Reset() {
+	*x = GetPriceRequest{}
+	mi := &file_fuelstation_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPriceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPriceRequest) ProtoMessage() {}
+
+func (x *GetPriceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fuelstation_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPriceRequest.ProtoReflect.Descriptor instead.
+func (*GetPriceRequest) Descriptor() ([]byte, []int) {
+	return file_fuelstation_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetPriceRequest) GetGrade() string {
+	if x != nil {
+		return x.Grade
+	}
+	return ""
+}
+
+type SetPriceRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	PricePerLiterKop int64                  `protobuf:"varint,1,opt,name=price_per_liter_kop,json=pricePerLiterKop,proto3" json:"price_per_liter_kop,omitempty"`
+	Grade            string                 `protobuf:"bytes,2,opt,name=grade,proto3" json:"grade,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *SetPriceRequest) Reset() {
+	*x = SetPriceRequest{}
+	mi := &file_fuelstation_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetPriceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetPriceRequest) ProtoMessage() {}
+
+func (x *SetPriceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fuelstation_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetPriceRequest.ProtoReflect.Descriptor instead.
+func (*SetPriceRequest) Descriptor() ([]byte, []int) {
+	return file_fuelstation_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *SetPriceRequest) GetPricePerLiterKop() int64 {
+	if x != nil {
+		return x.PricePerLiterKop
+	}
+	return 0
+}
+
+func (x *SetPriceRequest) GetGrade() string {
+	if x != nil {
+		return x.Grade
+	}
+	return ""
+}
+
+type CounterState struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         int64                  `protobuf:"varint,1,opt,name=value,proto3" json:"value,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Version       int64                  `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	DeviceId      string                 `protobuf:"bytes,4,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CounterState) Reset() {
+	*x = CounterState{}
+	mi := &file_fuelstation_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CounterState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CounterState) ProtoMessage() {}
+
+func (x *CounterState) ProtoReflect() protoreflect.Message {
+	mi := &file_fuelstation_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CounterState.ProtoReflect.Descriptor instead.
+func (*CounterState) Descriptor() ([]byte, []int) {
+	return file_fuelstation_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *CounterState) GetValue() int64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *CounterState) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *CounterState) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *CounterState) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+type GetCounterRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeviceId      string                 `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCounterRequest) Reset() {
+	*x = GetCounterRequest{}
+	mi := &file_fuelstation_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCounterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCounterRequest) ProtoMessage() {}
+
+func (x *GetCounterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fuelstation_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCounterRequest.ProtoReflect.Descriptor instead.
+func (*GetCounterRequest) Descriptor() ([]byte, []int) {
+	return file_fuelstation_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetCounterRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+type UpdateCounterRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         int64                  `protobuf:"varint,1,opt,name=value,proto3" json:"value,omitempty"`
+	Force         bool                   `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty"`
+	DeviceId      string                 `protobuf:"bytes,3,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateCounterRequest) Reset() {
+	*x = UpdateCounterRequest{}
+	mi := &file_fuelstation_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCounterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCounterRequest) ProtoMessage() {}
+
+func (x *UpdateCounterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fuelstation_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCounterRequest.ProtoReflect.Descriptor instead.
+func (*UpdateCounterRequest) Descriptor() ([]byte, []int) {
+	return file_fuelstation_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *UpdateCounterRequest) GetValue() int64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *UpdateCounterRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+func (x *UpdateCounterRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+type Device struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	IsActive      bool                   `protobuf:"varint,3,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Device) Reset() {
+	*x = Device{}
+	mi := &file_fuelstation_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Device) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Device) ProtoMessage() {}
+
+func (x *Device) ProtoReflect() protoreflect.Message {
+	mi := &file_fuelstation_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Device.ProtoReflect.Descriptor instead.
+func (*Device) Descriptor() ([]byte, []int) {
+	return file_fuelstation_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *Device) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Device) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Device) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+type ListDevicesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDevicesRequest) Reset() {
+	*x = ListDevicesRequest{}
+	mi := &file_fuelstation_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDevicesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDevicesRequest) ProtoMessage() {}
+
+func (x *ListDevicesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fuelstation_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDevicesRequest.ProtoReflect.Descriptor instead.
+func (*ListDevicesRequest) Descriptor() ([]byte, []int) {
+	return file_fuelstation_proto_rawDescGZIP(), []int{25}
+}
+
+type ListDevicesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Devices       []*Device              `protobuf:"bytes,1,rep,name=devices,proto3" json:"devices,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDevicesResponse) Reset() {
+	*x = ListDevicesResponse{}
+	mi := &file_fuelstation_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDevicesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDevicesResponse) ProtoMessage() {}
+
+func (x *ListDevicesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_fuelstation_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDevicesResponse.ProtoReflect.Descriptor instead.
+func (*ListDevicesResponse) Descriptor() ([]byte, []int) {
+	return file_fuelstation_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ListDevicesResponse) GetDevices() []*Device {
+	if x != nil {
+		return x.Devices
+	}
+	return nil
+}
+
+var File_fuelstation_proto protoreflect.FileDescriptor
+
+const file_fuelstation_proto_rawDesc = "" +
+	"\n" +
+	"\x11fuelstation.proto\x12\x0efuelstation.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xf1\x05\n" +
+	"\x0fRefuelOperation\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12&\n" +
+	"\x0famount_paid_kop\x18\x02 \x01(\x03R\ramountPaidKop\x12+\n" +
+	"\x11calculated_volume\x18\x03 \x01(\x03R\x10calculatedVolume\x12-\n" +
+	"\x13price_per_liter_kop\x18\x04 \x01(\x03R\x10pricePerLiterKop\x12%\n" +
+	"\x0ecounter_before\x18\x05 \x01(\x03R\rcounterBefore\x12#\n" +
+	"\rcounter_after\x18\x06 \x01(\x03R\fcounterAfter\x12\x16\n" +
+	"\x06status\x18\a \x01(\tR\x06status\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12=\n" +
+	"\fcancelled_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\vcancelledAt\x12(\n" +
+	"\rcancel_reason\x18\n" +
+	" \x01(\tH\x00R\fcancelReason\x88\x01\x01\x12\x14\n" +
+	"\x05grade\x18\v \x01(\tR\x05grade\x12\x1b\n" +
+	"\tdevice_id\x18\f \x01(\tR\bdeviceId\x12,\n" +
+	"\x0fidempotency_key\x18\r \x01(\tH\x01R\x0eidempotencyKey\x88\x01\x01\x12.\n" +
+	"\x10dispensed_volume\x18\x0e \x01(\x03H\x02R\x0fdispensedVolume\x88\x01\x01\x12$\n" +
+	"\x0erefund_due_kop\x18\x0f \x01(\x03R\frefundDueKop\x12!\n" +
+	"\frefunded_kop\x18\x10 \x01(\x03R\vrefundedKop\x12\x12\n" +
+	"\x04mode\x18\x11 \x01(\tR\x04mode\x12\x19\n" +
+	"\bpaid_kop\x18\x12 \x01(\x03R\apaidKopB\x10\n" +
+	"\x0e_cancel_reasonB\x12\n" +
+	"\x10_idempotency_keyB\x13\n" +
+	"\x11_dispensed_volume\"\xa5\x02\n" +
+	"\x13CreateRefuelRequest\x12&\n" +
+	"\x0famount_paid_kop\x18\x01 \x01(\x03R\ramountPaidKop\x12%\n" +
+	"\x0ecounter_before\x18\x02 \x01(\x03R\rcounterBefore\x12\x14\n" +
+	"\x05grade\x18\x03 \x01(\tR\x05grade\x12\x1b\n" +
+	"\tdevice_id\x18\x04 \x01(\tR\bdeviceId\x12'\n" +
+	"\x0fidempotency_key\x18\x05 \x01(\tR\x0eidempotencyKey\x12\x12\n" +
+	"\x04mode\x18\x06 \x01(\tR\x04mode\x12\x16\n" +
+	"\x06volume\x18\a \x01(\x03R\x06volume\x127\n" +
+	"\bpayments\x18\b \x03(\v2\x1b.fuelstation.v1.PaymentLineR\bpayments\"}\n" +
+	"\vPaymentLine\x12\x16\n" +
+	"\x06method\x18\x01 \x01(\tR\x06method\x12\x1d\n" +
+	"\n" +
+	"amount_kop\x18\x02 \x01(\x03R\tamountKop\x12&\n" +
+	"\fexternal_ref\x18\x03 \x01(\tH\x00R\vexternalRef\x88\x01\x01B\x0f\n" +
+	"\r_external_ref\"m\n" +
+	"\x11AddPaymentRequest\x12!\n" +
+	"\foperation_id\x18\x01 \x01(\tR\voperationId\x125\n" +
+	"\apayment\x18\x02 \x01(\v2\x1b.fuelstation.v1.PaymentLineR\apayment\"\xe7\x01\n" +
+	"\aPayment\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12!\n" +
+	"\foperation_id\x18\x02 \x01(\x03R\voperationId\x12\x16\n" +
+	"\x06method\x18\x03 \x01(\tR\x06method\x12\x1d\n" +
+	"\n" +
+	"amount_kop\x18\x04 \x01(\x03R\tamountKop\x12&\n" +
+	"\fexternal_ref\x18\x05 \x01(\tH\x00R\vexternalRef\x88\x01\x01\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAtB\x0f\n" +
+	"\r_external_ref\"b\n" +
+	"\x14ConfirmRefuelRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12(\n" +
+	"\rcounter_after\x18\x02 \x01(\x03H\x00R\fcounterAfter\x88\x01\x01B\x10\n" +
+	"\x0e_counter_after\"=\n" +
+	"\x13CancelRefuelRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"\"\n" +
+	"\x10GetRefuelRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"S\n" +
+	"\x1eStreamPendingOperationsRequest\x12\x14\n" +
+	"\x05watch\x18\x01 \x01(\bR\x05watch\x12\x1b\n" +
+	"\tdevice_id\x18\x02 \x01(\tR\bdeviceId\"r\n" +
+	"\x14GetStatisticsRequest\x12.\n" +
+	"\x04from\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\"\xa0\x06\n" +
+	"\n" +
+	"Statistics\x12)\n" +
+	"\x10total_operations\x18\x01 \x01(\x03R\x0ftotalOperations\x12*\n" +
+	"\x11total_revenue_kop\x18\x02 \x01(\x03R\x0ftotalRevenueKop\x12!\n" +
+	"\ftotal_volume\x18\x03 \x01(\x03R\vtotalVolume\x12%\n" +
+	"\x0eaverage_volume\x18\x04 \x01(\x03R\raverageVolume\x12,\n" +
+	"\x12average_amount_kop\x18\x05 \x01(\x03R\x10averageAmountKop\x12'\n" +
+	"\x0fconfirmed_count\x18\x06 \x01(\x03R\x0econfirmedCount\x12'\n" +
+	"\x0fcancelled_count\x18\a \x01(\x03R\x0ecancelledCount\x12#\n" +
+	"\rpending_count\x18\b \x01(\x03R\fpendingCount\x129\n" +
+	"\n" +
+	"start_date\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tstartDate\x125\n" +
+	"\bend_date\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\aendDate\x12:\n" +
+	"\bby_grade\x18\v \x03(\v2\x1f.fuelstation.v1.GradeStatisticsR\abyGrade\x12:\n" +
+	"\x19partially_fulfilled_count\x18\f \x01(\x03R\x17partiallyFulfilledCount\x12,\n" +
+	"\x12total_refunded_kop\x18\r \x01(\x03R\x10totalRefundedKop\x126\n" +
+	"\x17outstanding_refunds_kop\x18\x0e \x01(\x03R\x15outstandingRefundsKop\x12S\n" +
+	"\x11by_payment_method\x18\x0f \x03(\v2'.fuelstation.v1.PaymentMethodStatisticsR\x0fbyPaymentMethod\x12'\n" +
+	"\x0funallocated_kop\x18\x10 \x01(\x03R\x0eunallocatedKop\"\xad\x01\n" +
+	"\x17PaymentMethodStatistics\x12\x16\n" +
+	"\x06method\x18\x01 \x01(\tR\x06method\x12#\n" +
+	"\rpayment_count\x18\x02 \x01(\x03R\fpaymentCount\x12\x19\n" +
+	"\bpaid_kop\x18\x03 \x01(\x03R\apaidKop\x12!\n" +
+	"\frefunded_kop\x18\x04 \x01(\x03R\vrefundedKop\x12\x17\n" +
+	"\anet_kop\x18\x05 \x01(\x03R\x06netKop\"\x9f\x01\n" +
+	"\x0fGradeStatistics\x12\x14\n" +
+	"\x05grade\x18\x01 \x01(\tR\x05grade\x12'\n" +
+	"\x0fconfirmed_count\x18\x02 \x01(\x03R\x0econfirmedCount\x12*\n" +
+	"\x11total_revenue_kop\x18\x03 \x01(\x03R\x0ftotalRevenueKop\x12!\n" +
+	"\ftotal_volume\x18\x04 \x01(\x03R\vtotalVolume\"3\n" +
+	"\tFuelGrade\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"\x13\n" +
+	"\x11ListGradesRequest\"G\n" +
+	"\x12ListGradesResponse\x121\n" +
+	"\x06grades\x18\x01 \x03(\v2\x19.fuelstation.v1.FuelGradeR\x06grades\"\x19\n" +
+	"\x17ListActivePricesRequest\"M\n" +
+	"\x18ListActivePricesResponse\x121\n" +
+	"\x06prices\x18\x01 \x03(\v2\x19.fuelstation.v1.FuelPriceR\x06prices\"\xb8\x01\n" +
+	"\tFuelPrice\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12-\n" +
+	"\x13price_per_liter_kop\x18\x02 \x01(\x03R\x10pricePerLiterKop\x129\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x1b\n" +
+	"\tis_active\x18\x04 \x01(\bR\bisActive\x12\x14\n" +
+	"\x05grade\x18\x05 \x01(\tR\x05grade\"'\n" +
+	"\x0fGetPriceRequest\x12\x14\n" +
+	"\x05grade\x18\x01 \x01(\tR\x05grade\"V\n" +
+	"\x0fSetPriceRequest\x12-\n" +
+	"\x13price_per_liter_kop\x18\x01 \x01(\x03R\x10pricePerLiterKop\x12\x14\n" +
+	"\x05grade\x18\x02 \x01(\tR\x05grade\"\x96\x01\n" +
+	"\fCounterState\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\x03R\x05value\x129\n" +
+	"\n" +
+	"updated_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\x03R\aversion\x12\x1b\n" +
+	"\tdevice_id\x18\x04 \x01(\tR\bdeviceId\"0\n" +
+	"\x11GetCounterRequest\x12\x1b\n" +
+	"\tdevice_id\x18\x01 \x01(\tR\bdeviceId\"_\n" +
+	"\x14UpdateCounterRequest\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\x03R\x05value\x12\x14\n" +
+	"\x05force\x18\x02 \x01(\bR\x05force\x12\x1b\n" +
+	"\tdevice_id\x18\x03 \x01(\tR\bdeviceId\"I\n" +
+	"\x06Device\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1b\n" +
+	"\tis_active\x18\x03 \x01(\bR\bisActive\"\x14\n" +
+	"\x12ListDevicesRequest\"G\n" +
+	"\x13ListDevicesResponse\x120\n" +
+	"\adevices\x18\x01 \x03(\v2\x16.fuelstation.v1.DeviceR\adevices2\x87\n" +
+	"\n" +
+	"\x12FuelStationService\x12T\n" +
+	"\fCreateRefuel\x12#.fuelstation.v1.CreateRefuelRequest\x1a\x1f.fuelstation.v1.RefuelOperation\x12V\n" +
+	"\rConfirmRefuel\x12$.fuelstation.v1.ConfirmRefuelRequest\x1a\x1f.fuelstation.v1.RefuelOperation\x12T\n" +
+	"\fCancelRefuel\x12#.fuelstation.v1.CancelRefuelRequest\x1a\x1f.fuelstation.v1.RefuelOperation\x12N\n" +
+	"\tGetRefuel\x12 .fuelstation.v1.GetRefuelRequest\x1a\x1f.fuelstation.v1.RefuelOperation\x12H\n" +
+	"\n" +
+	"AddPayment\x12!.fuelstation.v1.AddPaymentRequest\x1a\x17.fuelstation.v1.Payment\x12l\n" +
+	"\x17StreamPendingOperations\x12..fuelstation.v1.StreamPendingOperationsRequest\x1a\x1f.fuelstation.v1.RefuelOperation0\x01\x12Q\n" +
+	"\rGetStatistics\x12$.fuelstation.v1.GetStatisticsRequest\x1a\x1a.fuelstation.v1.Statistics\x12S\n" +
+	"\n" +
+	"ListGrades\x12!.fuelstation.v1.ListGradesRequest\x1a\".fuelstation.v1.ListGradesResponse\x12e\n" +
+	"\x10ListActivePrices\x12'.fuelstation.v1.ListActivePricesRequest\x1a(.fuelstation.v1.ListActivePricesResponse\x12F\n" +
+	"\bGetPrice\x12\x1f.fuelstation.v1.GetPriceRequest\x1a\x19.fuelstation.v1.FuelPrice\x12G\n" +
+	"\tInitPrice\x12\x1f.fuelstation.v1.SetPriceRequest\x1a\x19.fuelstation.v1.FuelPrice\x12I\n" +
+	"\vChangePrice\x12\x1f.fuelstation.v1.SetPriceRequest\x1a\x19.fuelstation.v1.FuelPrice\x12V\n" +
+	"\vListDevices\x12\".fuelstation.v1.ListDevicesRequest\x1a#.fuelstation.v1.ListDevicesResponse\x12M\n" +
+	"\n" +
+	"GetCounter\x12!.fuelstation.v1.GetCounterRequest\x1a\x1c.fuelstation.v1.CounterState\x12S\n" +
+	"\rUpdateCounter\x12$.fuelstation.v1.UpdateCounterRequest\x1a\x1c.fuelstation.v1.CounterStateB.Z,fuelStation/api/fuelstation/v1;fuelstationv1b\x06proto3"
+
+var (
+	file_fuelstation_proto_rawDescOnce sync.Once
+	file_fuelstation_proto_rawDescData []byte
+)
+
+func file_fuelstation_proto_rawDescGZIP() []byte {
+	file_fuelstation_proto_rawDescOnce.Do(func() {
+		file_fuelstation_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_fuelstation_proto_rawDesc), len(file_fuelstation_proto_rawDesc)))
+	})
+	return file_fuelstation_proto_rawDescData
+}
+
+var file_fuelstation_proto_msgTypes = make([]protoimpl.MessageInfo, 27)
+var file_fuelstation_proto_goTypes = []any{
+	(*RefuelOperation)(nil),                // 0: fuelstation.v1.RefuelOperation
+	(*CreateRefuelRequest)(nil),            // 1: fuelstation.v1.CreateRefuelRequest
+	(*PaymentLine)(nil),                    // 2: fuelstation.v1.PaymentLine
+	(*AddPaymentRequest)(nil),              // 3: fuelstation.v1.AddPaymentRequest
+	(*Payment)(nil),                        // 4: fuelstation.v1.Payment
+	(*ConfirmRefuelRequest)(nil),           // 5: fuelstation.v1.ConfirmRefuelRequest
+	(*CancelRefuelRequest)(nil),            // 6: fuelstation.v1.CancelRefuelRequest
+	(*GetRefuelRequest)(nil),               // 7: fuelstation.v1.GetRefuelRequest
+	(*StreamPendingOperationsRequest)(nil), // 8: fuelstation.v1.StreamPendingOperationsRequest
+	(*GetStatisticsRequest)(nil),           // 9: fuelstation.v1.GetStatisticsRequest
+	(*Statistics)(nil),                     // 10: fuelstation.v1.Statistics
+	(*PaymentMethodStatistics)(nil),        // 11: fuelstation.v1.PaymentMethodStatistics
+	(*GradeStatistics)(nil),                // 12: fuelstation.v1.GradeStatistics
+	(*FuelGrade)(nil),                      // 13: fuelstation.v1.FuelGrade
+	(*ListGradesRequest)(nil),              // 14: fuelstation.v1.ListGradesRequest
+	(*ListGradesResponse)(nil),             // 15: fuelstation.v1.ListGradesResponse
+	(*ListActivePricesRequest)(nil),        // 16: fuelstation.v1.ListActivePricesRequest
+	(*ListActivePricesResponse)(nil),       // 17: fuelstation.v1.ListActivePricesResponse
+	(*FuelPrice)(nil),                      // 18: fuelstation.v1.FuelPrice
+	(*GetPriceRequest)(nil),                // 19: fuelstation.v1.GetPriceRequest
+	(*SetPriceRequest)(nil),                // 20: fuelstation.v1.SetPriceRequest
+	(*CounterState)(nil),                   // 21: fuelstation.v1.CounterState
+	(*GetCounterRequest)(nil),              // 22: fuelstation.v1.GetCounterRequest
+	(*UpdateCounterRequest)(nil),           // 23: fuelstation.v1.UpdateCounterRequest
+	(*Device)(nil),                         // 24: fuelstation.v1.Device
+	(*ListDevicesRequest)(nil),             // 25: fuelstation.v1.ListDevicesRequest
+	(*ListDevicesResponse)(nil),            // 26: fuelstation.v1.ListDevicesResponse
+	(*timestamppb.Timestamp)(nil),          // 27: google.protobuf.Timestamp
+}
+var file_fuelstation_proto_depIdxs = []int32{
+	27, // 0: fuelstation.v1.RefuelOperation.created_at:type_name -> google.protobuf.Timestamp
+	27, // 1: fuelstation.v1.RefuelOperation.cancelled_at:type_name -> google.protobuf.Timestamp
+	2,  // 2: fuelstation.v1.CreateRefuelRequest.payments:type_name -> fuelstation.v1.PaymentLine
+	2,  // 3: fuelstation.v1.AddPaymentRequest.payment:type_name -> fuelstation.v1.PaymentLine
+	27, // 4: fuelstation.v1.Payment.created_at:type_name -> google.protobuf.Timestamp
+	27, // 5: fuelstation.v1.GetStatisticsRequest.from:type_name -> google.protobuf.Timestamp
+	27, // 6: fuelstation.v1.GetStatisticsRequest.to:type_name -> google.protobuf.Timestamp
+	27, // 7: fuelstation.v1.Statistics.start_date:type_name -> google.protobuf.Timestamp
+	27, // 8: fuelstation.v1.Statistics.end_date:type_name -> google.protobuf.Timestamp
+	12, // 9: fuelstation.v1.Statistics.by_grade:type_name -> fuelstation.v1.GradeStatistics
+	11, // 10: fuelstation.v1.Statistics.by_payment_method:type_name -> fuelstation.v1.PaymentMethodStatistics
+	13, // 11: fuelstation.v1.ListGradesResponse.grades:type_name -> fuelstation.v1.FuelGrade
+	18, // 12: fuelstation.v1.ListActivePricesResponse.prices:type_name -> fuelstation.v1.FuelPrice
+	27, // 13: fuelstation.v1.FuelPrice.created_at:type_name -> google.protobuf.Timestamp
+	27, // 14: fuelstation.v1.CounterState.updated_at:type_name -> google.protobuf.Timestamp
+	24, // 15: fuelstation.v1.ListDevicesResponse.devices:type_name -> fuelstation.v1.Device
+	1,  // 16: fuelstation.v1.FuelStationService.CreateRefuel:input_type -> fuelstation.v1.CreateRefuelRequest
+	5,  // 17: fuelstation.v1.FuelStationService.ConfirmRefuel:input_type -> fuelstation.v1.ConfirmRefuelRequest
+	6,  // 18: fuelstation.v1.FuelStationService.CancelRefuel:input_type -> fuelstation.v1.CancelRefuelRequest
+	7,  // 19: fuelstation.v1.FuelStationService.GetRefuel:input_type -> fuelstation.v1.GetRefuelRequest
+	3,  // 20: fuelstation.v1.FuelStationService.AddPayment:input_type -> fuelstation.v1.AddPaymentRequest
+	8,  // 21: fuelstation.v1.FuelStationService.StreamPendingOperations:input_type -> fuelstation.v1.StreamPendingOperationsRequest
+	9,  // 22: fuelstation.v1.FuelStationService.GetStatistics:input_type -> fuelstation.v1.GetStatisticsRequest
+	14, // 23: fuelstation.v1.FuelStationService.ListGrades:input_type -> fuelstation.v1.ListGradesRequest
+	16, // 24: fuelstation.v1.FuelStationService.ListActivePrices:input_type -> fuelstation.v1.ListActivePricesRequest
+	19, // 25: fuelstation.v1.FuelStationService.GetPrice:input_type -> fuelstation.v1.GetPriceRequest
+	20, // 26: fuelstation.v1.FuelStationService.InitPrice:input_type -> fuelstation.v1.SetPriceRequest
+	20, // 27: fuelstation.v1.FuelStationService.ChangePrice:input_type -> fuelstation.v1.SetPriceRequest
+	25, // 28: fuelstation.v1.FuelStationService.ListDevices:input_type -> fuelstation.v1.ListDevicesRequest
+	22, // 29: fuelstation.v1.FuelStationService.GetCounter:input_type -> fuelstation.v1.GetCounterRequest
+	23, // 30: fuelstation.v1.FuelStationService.UpdateCounter:input_type -> fuelstation.v1.UpdateCounterRequest
+	0,  // 31: fuelstation.v1.FuelStationService.CreateRefuel:output_type -> fuelstation.v1.RefuelOperation
+	0,  // 32: fuelstation.v1.FuelStationService.ConfirmRefuel:output_type -> fuelstation.v1.RefuelOperation
+	0,  // 33: fuelstation.v1.FuelStationService.CancelRefuel:output_type -> fuelstation.v1.RefuelOperation
+	0,  // 34: fuelstation.v1.FuelStationService.GetRefuel:output_type -> fuelstation.v1.RefuelOperation
+	4,  // 35: fuelstation.v1.FuelStationService.AddPayment:output_type -> fuelstation.v1.Payment
+	0,  // 36: fuelstation.v1.FuelStationService.StreamPendingOperations:output_type -> fuelstation.v1.RefuelOperation
+	10, // 37: fuelstation.v1.FuelStationService.GetStatistics:output_type -> fuelstation.v1.Statistics
+	15, // 38: fuelstation.v1.FuelStationService.ListGrades:output_type -> fuelstation.v1.ListGradesResponse
+	17, // 39: fuelstation.v1.FuelStationService.ListActivePrices:output_type -> fuelstation.v1.ListActivePricesResponse
+	18, // 40: fuelstation.v1.FuelStationService.GetPrice:output_type -> fuelstation.v1.FuelPrice
+	18, // 41: fuelstation.v1.FuelStationService.InitPrice:output_type -> fuelstation.v1.FuelPrice
+	18, // 42: fuelstation.v1.FuelStationService.ChangePrice:output_type -> fuelstation.v1.FuelPrice
+	26, // 43: fuelstation.v1.FuelStationService.ListDevices:output_type -> fuelstation.v1.ListDevicesResponse
+	21, // 44: fuelstation.v1.FuelStationService.GetCounter:output_type -> fuelstation.v1.CounterState
+	21, // 45: fuelstation.v1.FuelStationService.UpdateCounter:output_type -> fuelstation.v1.CounterState
+	31, // [31:46] is the sub-list for method output_type
+	16, // [16:31] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
+}
+
+func init() { file_fuelstation_proto_init() }
+func file_fuelstation_proto_init() {
+	if File_fuelstation_proto != nil {
+		return
+	}
+	file_fuelstation_proto_msgTypes[0].OneofWrappers = []any{}
+	file_fuelstation_proto_msgTypes[2].OneofWrappers = []any{}
+	file_fuelstation_proto_msgTypes[4].OneofWrappers = []any{}
+	file_fuelstation_proto_msgTypes[5].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_fuelstation_proto_rawDesc), len(file_fuelstation_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   27,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_fuelstation_proto_goTypes,
+		DependencyIndexes: file_fuelstation_proto_depIdxs,
+		MessageInfos:      file_fuelstation_proto_msgTypes,
+	}.Build()
+	File_fuelstation_proto = out.File
+	file_fuelstation_proto_goTypes = nil
+	file_fuelstation_proto_depIdxs = nil
+}
@@ -0,0 +1,657 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: fuelstation.proto
+
+package fuelstationv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	FuelStationService_CreateRefuel_FullMethodName            = "/fuelstation.v1.FuelStationService/CreateRefuel"
+	FuelStationService_ConfirmRefuel_FullMethodName           = "/fuelstation.v1.FuelStationService/ConfirmRefuel"
+	FuelStationService_CancelRefuel_FullMethodName            = "/fuelstation.v1.FuelStationService/CancelRefuel"
+	FuelStationService_GetRefuel_FullMethodName               = "/fuelstation.v1.FuelStationService/GetRefuel"
+	FuelStationService_AddPayment_FullMethodName              = "/fuelstation.v1.FuelStationService/AddPayment"
+	FuelStationService_StreamPendingOperations_FullMethodName = "/fuelstation.v1.FuelStationService/StreamPendingOperations"
+	FuelStationService_GetStatistics_FullMethodName           = "/fuelstation.v1.FuelStationService/GetStatistics"
+	FuelStationService_ListGrades_FullMethodName              = "/fuelstation.v1.FuelStationService/ListGrades"
+	FuelStationService_ListActivePrices_FullMethodName        = "/fuelstation.v1.FuelStationService/ListActivePrices"
+	FuelStationService_GetPrice_FullMethodName                = "/fuelstation.v1.FuelStationService/GetPrice"
+	FuelStationService_InitPrice_FullMethodName               = "/fuelstation.v1.FuelStationService/InitPrice"
+	FuelStationService_ChangePrice_FullMethodName             = "/fuelstation.v1.FuelStationService/ChangePrice"
+	FuelStationService_ListDevices_FullMethodName             = "/fuelstation.v1.FuelStationService/ListDevices"
+	FuelStationService_GetCounter_FullMethodName              = "/fuelstation.v1.FuelStationService/GetCounter"
+	FuelStationService_UpdateCounter_FullMethodName           = "/fuelstation.v1.FuelStationService/UpdateCounter"
+)
+
+// FuelStationServiceClient is the client API for FuelStationService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type FuelStationServiceClient interface {
+	CreateRefuel(ctx context.Context, in *CreateRefuelRequest, opts ...grpc.CallOption) (*RefuelOperation, error)
+	ConfirmRefuel(ctx context.Context, in *ConfirmRefuelRequest, opts ...grpc.CallOption) (*RefuelOperation, error)
+	CancelRefuel(ctx context.Context, in *CancelRefuelRequest, opts ...grpc.CallOption) (*RefuelOperation, error)
+	GetRefuel(ctx context.Context, in *GetRefuelRequest, opts ...grpc.CallOption) (*RefuelOperation, error)
+	AddPayment(ctx context.Context, in *AddPaymentRequest, opts ...grpc.CallOption) (*Payment, error)
+	StreamPendingOperations(ctx context.Context, in *StreamPendingOperationsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[RefuelOperation], error)
+	GetStatistics(ctx context.Context, in *GetStatisticsRequest, opts ...grpc.CallOption) (*Statistics, error)
+	ListGrades(ctx context.Context, in *ListGradesRequest, opts ...grpc.CallOption) (*ListGradesResponse, error)
+	ListActivePrices(ctx context.Context, in *ListActivePricesRequest, opts ...grpc.CallOption) (*ListActivePricesResponse, error)
+	GetPrice(ctx context.Context, in *GetPriceRequest, opts ...grpc.CallOption) (*FuelPrice, error)
+	InitPrice(ctx context.Context, in *SetPriceRequest, opts ...grpc.CallOption) (*FuelPrice, error)
+	ChangePrice(ctx context.Context, in *SetPriceRequest, opts ...grpc.CallOption) (*FuelPrice, error)
+	ListDevices(ctx context.Context, in *ListDevicesRequest, opts ...grpc.CallOption) (*ListDevicesResponse, error)
+	GetCounter(ctx context.Context, in *GetCounterRequest, opts ...grpc.CallOption) (*CounterState, error)
+	UpdateCounter(ctx context.Context, in *UpdateCounterRequest, opts ...grpc.CallOption) (*CounterState, error)
+}
+
+type fuelStationServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewFuelStationServiceClient(cc grpc.ClientConnInterface) FuelStationServiceClient {
+	return &fuelStationServiceClient{cc}
+}
+
+func (c *fuelStationServiceClient) CreateRefuel(ctx context.Context, in *CreateRefuelRequest, opts ...grpc.CallOption) (*RefuelOperation, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RefuelOperation)
+	err := c.cc.Invoke(ctx, FuelStationService_CreateRefuel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fuelStationServiceClient) ConfirmRefuel(ctx context.Context, in *ConfirmRefuelRequest, opts ...grpc.CallOption) (*RefuelOperation, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RefuelOperation)
+	err := c.cc.Invoke(ctx, FuelStationService_ConfirmRefuel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fuelStationServiceClient) CancelRefuel(ctx context.Context, in *CancelRefuelRequest, opts ...grpc.CallOption) (*RefuelOperation, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RefuelOperation)
+	err := c.cc.Invoke(ctx, FuelStationService_CancelRefuel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fuelStationServiceClient) GetRefuel(ctx context.Context, in *GetRefuelRequest, opts ...grpc.CallOption) (*RefuelOperation, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RefuelOperation)
+	err := c.cc.Invoke(ctx, FuelStationService_GetRefuel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fuelStationServiceClient) AddPayment(ctx context.Context, in *AddPaymentRequest, opts ...grpc.CallOption) (*Payment, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Payment)
+	err := c.cc.Invoke(ctx, FuelStationService_AddPayment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fuelStationServiceClient) StreamPendingOperations(ctx context.Context, in *StreamPendingOperationsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[RefuelOperation], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &FuelStationService_ServiceDesc.Streams[0], FuelStationService_StreamPendingOperations_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamPendingOperationsRequest, RefuelOperation]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FuelStationService_StreamPendingOperationsClient = grpc.ServerStreamingClient[RefuelOperation]
+
+func (c *fuelStationServiceClient) GetStatistics(ctx context.Context, in *GetStatisticsRequest, opts ...grpc.CallOption) (*Statistics, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Statistics)
+	err := c.cc.Invoke(ctx, FuelStationService_GetStatistics_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fuelStationServiceClient) ListGrades(ctx context.Context, in *ListGradesRequest, opts ...grpc.CallOption) (*ListGradesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListGradesResponse)
+	err := c.cc.Invoke(ctx, FuelStationService_ListGrades_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fuelStationServiceClient) ListActivePrices(ctx context.Context, in *ListActivePricesRequest, opts ...grpc.CallOption) (*ListActivePricesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListActivePricesResponse)
+	err := c.cc.Invoke(ctx, FuelStationService_ListActivePrices_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fuelStationServiceClient) GetPrice(ctx context.Context, in *GetPriceRequest, opts ...grpc.CallOption) (*FuelPrice, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FuelPrice)
+	err := c.cc.Invoke(ctx, FuelStationService_GetPrice_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fuelStationServiceClient) InitPrice(ctx context.Context, in *SetPriceRequest, opts ...grpc.CallOption) (*FuelPrice, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FuelPrice)
+	err := c.cc.Invoke(ctx, FuelStationService_InitPrice_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fuelStationServiceClient) ChangePrice(ctx context.Context, in *SetPriceRequest, opts ...grpc.CallOption) (*FuelPrice, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FuelPrice)
+	err := c.cc.Invoke(ctx, FuelStationService_ChangePrice_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fuelStationServiceClient) ListDevices(ctx context.Context, in *ListDevicesRequest, opts ...grpc.CallOption) (*ListDevicesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDevicesResponse)
+	err := c.cc.Invoke(ctx, FuelStationService_ListDevices_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fuelStationServiceClient) GetCounter(ctx context.Context, in *GetCounterRequest, opts ...grpc.CallOption) (*CounterState, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CounterState)
+	err := c.cc.Invoke(ctx, FuelStationService_GetCounter_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fuelStationServiceClient) UpdateCounter(ctx context.Context, in *UpdateCounterRequest, opts ...grpc.CallOption) (*CounterState, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CounterState)
+	err := c.cc.Invoke(ctx, FuelStationService_UpdateCounter_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FuelStationServiceServer is the server API for FuelStationService service.
+// All implementations must embed UnimplementedFuelStationServiceServer
+// for forward compatibility.
+type FuelStationServiceServer interface {
+	CreateRefuel(context.Context, *CreateRefuelRequest) (*RefuelOperation, error)
+	ConfirmRefuel(context.Context, *ConfirmRefuelRequest) (*RefuelOperation, error)
+	CancelRefuel(context.Context, *CancelRefuelRequest) (*RefuelOperation, error)
+	GetRefuel(context.Context, *GetRefuelRequest) (*RefuelOperation, error)
+	AddPayment(context.Context, *AddPaymentRequest) (*Payment, error)
+	StreamPendingOperations(*StreamPendingOperationsRequest, grpc.ServerStreamingServer[RefuelOperation]) error
+	GetStatistics(context.Context, *GetStatisticsRequest) (*Statistics, error)
+	ListGrades(context.Context, *ListGradesRequest) (*ListGradesResponse, error)
+	ListActivePrices(context.Context, *ListActivePricesRequest) (*ListActivePricesResponse, error)
+	GetPrice(context.Context, *GetPriceRequest) (*FuelPrice, error)
+	InitPrice(context.Context, *SetPriceRequest) (*FuelPrice, error)
+	ChangePrice(context.Context, *SetPriceRequest) (*FuelPrice, error)
+	ListDevices(context.Context, *ListDevicesRequest) (*ListDevicesResponse, error)
+	GetCounter(context.Context, *GetCounterRequest) (*CounterState, error)
+	UpdateCounter(context.Context, *UpdateCounterRequest) (*CounterState, error)
+	mustEmbedUnimplementedFuelStationServiceServer()
+}
+
+// UnimplementedFuelStationServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedFuelStationServiceServer struct{}
+
+func (UnimplementedFuelStationServiceServer) CreateRefuel(context.Context, *CreateRefuelRequest) (*RefuelOperation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateRefuel not implemented")
+}
+func (UnimplementedFuelStationServiceServer) ConfirmRefuel(context.Context, *ConfirmRefuelRequest) (*RefuelOperation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfirmRefuel not implemented")
+}
+func (UnimplementedFuelStationServiceServer) CancelRefuel(context.Context, *CancelRefuelRequest) (*RefuelOperation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelRefuel not implemented")
+}
+func (UnimplementedFuelStationServiceServer) GetRefuel(context.Context, *GetRefuelRequest) (*RefuelOperation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRefuel not implemented")
+}
+func (UnimplementedFuelStationServiceServer) AddPayment(context.Context, *AddPaymentRequest) (*Payment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddPayment not implemented")
+}
+func (UnimplementedFuelStationServiceServer) StreamPendingOperations(*StreamPendingOperationsRequest, grpc.ServerStreamingServer[RefuelOperation]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamPendingOperations not implemented")
+}
+func (UnimplementedFuelStationServiceServer) GetStatistics(context.Context, *GetStatisticsRequest) (*Statistics, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStatistics not implemented")
+}
+func (UnimplementedFuelStationServiceServer) ListGrades(context.Context, *ListGradesRequest) (*ListGradesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListGrades not implemented")
+}
+func (UnimplementedFuelStationServiceServer) ListActivePrices(context.Context, *ListActivePricesRequest) (*ListActivePricesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListActivePrices not implemented")
+}
+func (UnimplementedFuelStationServiceServer) GetPrice(context.Context, *GetPriceRequest) (*FuelPrice, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPrice not implemented")
+}
+func (UnimplementedFuelStationServiceServer) InitPrice(context.Context, *SetPriceRequest) (*FuelPrice, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InitPrice not implemented")
+}
+func (UnimplementedFuelStationServiceServer) ChangePrice(context.Context, *SetPriceRequest) (*FuelPrice, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangePrice not implemented")
+}
+func (UnimplementedFuelStationServiceServer) ListDevices(context.Context, *ListDevicesRequest) (*ListDevicesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDevices not implemented")
+}
+func (UnimplementedFuelStationServiceServer) GetCounter(context.Context, *GetCounterRequest) (*CounterState, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCounter not implemented")
+}
+func (UnimplementedFuelStationServiceServer) UpdateCounter(context.Context, *UpdateCounterRequest) (*CounterState, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateCounter not implemented")
+}
+func (UnimplementedFuelStationServiceServer) mustEmbedUnimplementedFuelStationServiceServer() {}
+func (UnimplementedFuelStationServiceServer) testEmbeddedByValue()                            {}
+
+// UnsafeFuelStationServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to FuelStationServiceServer will
+// result in compilation errors.
+type UnsafeFuelStationServiceServer interface {
+	mustEmbedUnimplementedFuelStationServiceServer()
+}
+
+func RegisterFuelStationServiceServer(s grpc.ServiceRegistrar, srv FuelStationServiceServer) {
+	// If the following call pancis, it indicates UnimplementedFuelStationServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&FuelStationService_ServiceDesc, srv)
+}
+
+func _FuelStationService_CreateRefuel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRefuelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FuelStationServiceServer).CreateRefuel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FuelStationService_CreateRefuel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FuelStationServiceServer).CreateRefuel(ctx, req.(*CreateRefuelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FuelStationService_ConfirmRefuel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfirmRefuelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FuelStationServiceServer).ConfirmRefuel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FuelStationService_ConfirmRefuel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FuelStationServiceServer).ConfirmRefuel(ctx, req.(*ConfirmRefuelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FuelStationService_CancelRefuel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelRefuelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FuelStationServiceServer).CancelRefuel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FuelStationService_CancelRefuel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FuelStationServiceServer).CancelRefuel(ctx, req.(*CancelRefuelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FuelStationService_GetRefuel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRefuelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FuelStationServiceServer).GetRefuel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FuelStationService_GetRefuel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FuelStationServiceServer).GetRefuel(ctx, req.(*GetRefuelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FuelStationService_AddPayment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddPaymentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FuelStationServiceServer).AddPayment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FuelStationService_AddPayment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FuelStationServiceServer).AddPayment(ctx, req.(*AddPaymentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FuelStationService_StreamPendingOperations_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamPendingOperationsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FuelStationServiceServer).StreamPendingOperations(m, &grpc.GenericServerStream[StreamPendingOperationsRequest, RefuelOperation]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FuelStationService_StreamPendingOperationsServer = grpc.ServerStreamingServer[RefuelOperation]
+
+func _FuelStationService_GetStatistics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStatisticsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FuelStationServiceServer).GetStatistics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FuelStationService_GetStatistics_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FuelStationServiceServer).GetStatistics(ctx, req.(*GetStatisticsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FuelStationService_ListGrades_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListGradesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FuelStationServiceServer).ListGrades(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FuelStationService_ListGrades_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FuelStationServiceServer).ListGrades(ctx, req.(*ListGradesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FuelStationService_ListActivePrices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListActivePricesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FuelStationServiceServer).ListActivePrices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FuelStationService_ListActivePrices_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FuelStationServiceServer).ListActivePrices(ctx, req.(*ListActivePricesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FuelStationService_GetPrice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPriceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FuelStationServiceServer).GetPrice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FuelStationService_GetPrice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FuelStationServiceServer).GetPrice(ctx, req.(*GetPriceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FuelStationService_InitPrice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetPriceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FuelStationServiceServer).InitPrice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FuelStationService_InitPrice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FuelStationServiceServer).InitPrice(ctx, req.(*SetPriceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FuelStationService_ChangePrice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetPriceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FuelStationServiceServer).ChangePrice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FuelStationService_ChangePrice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FuelStationServiceServer).ChangePrice(ctx, req.(*SetPriceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FuelStationService_ListDevices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDevicesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FuelStationServiceServer).ListDevices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FuelStationService_ListDevices_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FuelStationServiceServer).ListDevices(ctx, req.(*ListDevicesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FuelStationService_GetCounter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCounterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FuelStationServiceServer).GetCounter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FuelStationService_GetCounter_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FuelStationServiceServer).GetCounter(ctx, req.(*GetCounterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FuelStationService_UpdateCounter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateCounterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FuelStationServiceServer).UpdateCounter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FuelStationService_UpdateCounter_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FuelStationServiceServer).UpdateCounter(ctx, req.(*UpdateCounterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// FuelStationService_ServiceDesc is the grpc.ServiceDesc for FuelStationService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var FuelStationService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "fuelstation.v1.FuelStationService",
+	HandlerType: (*FuelStationServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateRefuel",
+			Handler:    _FuelStationService_CreateRefuel_Handler,
+		},
+		{
+			MethodName: "ConfirmRefuel",
+			Handler:    _FuelStationService_ConfirmRefuel_Handler,
+		},
+		{
+			MethodName: "CancelRefuel",
+			Handler:    _FuelStationService_CancelRefuel_Handler,
+		},
+		{
+			MethodName: "GetRefuel",
+			Handler:    _FuelStationService_GetRefuel_Handler,
+		},
+		{
+			MethodName: "AddPayment",
+			Handler:    _FuelStationService_AddPayment_Handler,
+		},
+		{
+			MethodName: "GetStatistics",
+			Handler:    _FuelStationService_GetStatistics_Handler,
+		},
+		{
+			MethodName: "ListGrades",
+			Handler:    _FuelStationService_ListGrades_Handler,
+		},
+		{
+			MethodName: "ListActivePrices",
+			Handler:    _FuelStationService_ListActivePrices_Handler,
+		},
+		{
+			MethodName: "GetPrice",
+			Handler:    _FuelStationService_GetPrice_Handler,
+		},
+		{
+			MethodName: "InitPrice",
+			Handler:    _FuelStationService_InitPrice_Handler,
+		},
+		{
+			MethodName: "ChangePrice",
+			Handler:    _FuelStationService_ChangePrice_Handler,
+		},
+		{
+			MethodName: "ListDevices",
+			Handler:    _FuelStationService_ListDevices_Handler,
+		},
+		{
+			MethodName: "GetCounter",
+			Handler:    _FuelStationService_GetCounter_Handler,
+		},
+		{
+			MethodName: "UpdateCounter",
+			Handler:    _FuelStationService_UpdateCounter_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamPendingOperations",
+			Handler:       _FuelStationService_StreamPendingOperations_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "fuelstation.proto",
+}
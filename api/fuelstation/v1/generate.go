@@ -0,0 +1,5 @@
+// Package fuelstationv1 содержит gRPC API для терминалов колонок.
+// Код *.pb.go сгенерирован из fuelstation.proto, руками не править.
+package fuelstationv1
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative fuelstation.proto
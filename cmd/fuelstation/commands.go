@@ -0,0 +1,645 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"fuelStation/internal/app"
+	"fuelStation/internal/domain/entity"
+	"fuelStation/internal/domain/service"
+	"strconv"
+	"time"
+)
+
+type cli struct {
+	app *app.App
+	out *printer
+}
+
+// Команды price
+func (c *cli) price(ctx context.Context, args []string) error {
+	if len(args) < 1 {
+		return errUsage
+	}
+
+	switch args[0] {
+	case "show":
+		fs := flag.NewFlagSet("price show", flag.ContinueOnError)
+		grade := fs.String("grade", "", "марка топлива (по умолчанию все)")
+		if _, err := parseFlags(fs, args[1:]); err != nil {
+			return errUsage
+		}
+
+		if *grade == "" {
+			prices, err := c.app.UseCase.GetActivePrices(ctx)
+			if err != nil {
+				return err
+			}
+			return c.out.prices(prices)
+		}
+
+		price, err := c.app.UseCase.GetPricePerLiter(ctx, *grade)
+		if err != nil {
+			return err
+		}
+		return c.out.prices([]entity.FuelPrice{price})
+
+	case "set":
+		fs := flag.NewFlagSet("price set", flag.ContinueOnError)
+		first := fs.Bool("init", false, "первая установка цены")
+		pos, err := parseFlags(fs, args[1:])
+		if err != nil || len(pos) != 2 {
+			return errUsage
+		}
+
+		value, err := entity.ParseMoney(pos[1])
+		if err != nil {
+			return err
+		}
+
+		var price entity.FuelPrice
+		if *first {
+			price, err = c.app.UseCase.InitPricePerLiter(ctx, pos[0], value)
+		} else {
+			price, err = c.app.UseCase.ChangePricePerLiter(ctx, pos[0], value)
+		}
+		if err != nil {
+			return err
+		}
+		return c.out.prices([]entity.FuelPrice{price})
+
+	case "history":
+		fs := flag.NewFlagSet("price history", flag.ContinueOnError)
+		grade := fs.String("grade", "", "марка топлива (по умолчанию все)")
+		from, to := periodFlags(fs)
+		if _, err := parseFlags(fs, args[1:]); err != nil {
+			return errUsage
+		}
+
+		prices, err := c.app.UseCase.GetPriceHistory(ctx, from.Time, to.Time, *grade)
+		if err != nil {
+			return err
+		}
+		return c.out.prices(prices)
+
+	case "intervals":
+		fs := flag.NewFlagSet("price intervals", flag.ContinueOnError)
+		grade := fs.String("grade", "", "марка топлива (по умолчанию все)")
+		from, to := periodFlags(fs)
+		if _, err := parseFlags(fs, args[1:]); err != nil {
+			return errUsage
+		}
+
+		intervals, err := c.app.UseCase.GetPriceIntervals(ctx, from.Time, to.Time, *grade)
+		if err != nil {
+			return err
+		}
+		return c.out.priceIntervals(intervals)
+
+	case "at":
+		fs := flag.NewFlagSet("price at", flag.ContinueOnError)
+		at := &timeFlag{}
+		fs.Var(at, "at", "момент времени")
+		pos, err := parseFlags(fs, args[1:])
+		if err != nil || len(pos) != 1 || at.IsZero() {
+			return errUsage
+		}
+
+		price, err := c.app.UseCase.GetPriceAt(ctx, pos[0], at.Time)
+		if err != nil {
+			return err
+		}
+		return c.out.prices([]entity.FuelPrice{price})
+
+	case "schedule":
+		fs := flag.NewFlagSet("price schedule", flag.ContinueOnError)
+		at := &timeFlag{}
+		fs.Var(at, "at", "с какого момента действует цена")
+		pos, err := parseFlags(fs, args[1:])
+		if err != nil || len(pos) != 2 || at.IsZero() {
+			return errUsage
+		}
+
+		value, err := entity.ParseMoney(pos[1])
+		if err != nil {
+			return err
+		}
+
+		scheduled, err := c.app.UseCase.SchedulePrice(ctx, pos[0], value, at.Time)
+		if err != nil {
+			return err
+		}
+		return c.out.scheduledPrices([]entity.ScheduledPrice{scheduled})
+
+	case "scheduled":
+		fs := flag.NewFlagSet("price scheduled", flag.ContinueOnError)
+		grade := fs.String("grade", "", "марка топлива (по умолчанию все)")
+		status := fs.String("status", service.ScheduledPriceStatusPending, "статус: Pending, Applied, Cancelled, Failed; пусто — все")
+		if _, err := parseFlags(fs, args[1:]); err != nil {
+			return errUsage
+		}
+
+		prices, err := c.app.UseCase.GetScheduledPrices(ctx, *grade, *status)
+		if err != nil {
+			return err
+		}
+		return c.out.scheduledPrices(prices)
+
+	case "unschedule":
+		if len(args) != 2 {
+			return errUsage
+		}
+		scheduled, err := c.app.UseCase.CancelScheduledPrice(ctx, args[1])
+		if err != nil {
+			return err
+		}
+		return c.out.scheduledPrices([]entity.ScheduledPrice{scheduled})
+
+	default:
+		return errUsage
+	}
+}
+
+// Команды grade
+func (c *cli) grade(ctx context.Context, args []string) error {
+	if len(args) < 1 {
+		return errUsage
+	}
+
+	switch args[0] {
+	case "list":
+		grades, err := c.app.UseCase.GetFuelGrades(ctx)
+		if err != nil {
+			return err
+		}
+		return c.out.grades(grades)
+
+	case "add":
+		if len(args) != 3 {
+			return errUsage
+		}
+		grade, err := c.app.UseCase.CreateFuelGrade(ctx, args[1], args[2])
+		if err != nil {
+			return err
+		}
+		return c.out.grades([]entity.FuelGrade{grade})
+
+	default:
+		return errUsage
+	}
+}
+
+// Команды device
+func (c *cli) device(ctx context.Context, args []string) error {
+	if len(args) < 1 {
+		return errUsage
+	}
+
+	switch args[0] {
+	case "list":
+		devices, err := c.app.UseCase.GetDevices(ctx)
+		if err != nil {
+			return err
+		}
+		return c.out.devices(devices)
+
+	case "add":
+		if len(args) != 3 {
+			return errUsage
+		}
+		device, err := c.app.UseCase.CreateDevice(ctx, args[1], args[2])
+		if err != nil {
+			return err
+		}
+		return c.out.devices([]entity.Device{device})
+
+	case "enable", "disable":
+		if len(args) != 2 {
+			return errUsage
+		}
+		device, err := c.app.UseCase.SetDeviceActive(ctx, args[1], args[0] == "enable")
+		if err != nil {
+			return err
+		}
+		return c.out.devices([]entity.Device{device})
+
+	default:
+		return errUsage
+	}
+}
+
+// Команды counter
+func (c *cli) counter(ctx context.Context, args []string) error {
+	if len(args) < 1 {
+		return errUsage
+	}
+
+	switch args[0] {
+	case "show":
+		if len(args) != 2 {
+			return errUsage
+		}
+		counter, err := c.app.UseCase.GetCurrent(ctx, args[1])
+		if err != nil {
+			return err
+		}
+		return c.out.counter(counter)
+
+	case "set":
+		fs := flag.NewFlagSet("counter set", flag.ContinueOnError)
+		force := fs.Bool("force", false, "без проверки на уменьшение")
+		pos, err := parseFlags(fs, args[1:])
+		if err != nil || len(pos) != 2 {
+			return errUsage
+		}
+
+		value, err := strconv.Atoi(pos[1])
+		if err != nil {
+			return fmt.Errorf("некорректное значение счётчика %q", pos[1])
+		}
+
+		var counter entity.CounterState
+		if *force {
+			counter, err = c.app.UseCase.UpdateCounter(ctx, pos[0], value)
+		} else {
+			counter, err = c.app.UseCase.UpdateCounterDuringRefuel(ctx, pos[0], value)
+		}
+		if err != nil {
+			return err
+		}
+		return c.out.counter(counter)
+
+	default:
+		return errUsage
+	}
+}
+
+// Команды refuel
+func (c *cli) refuel(ctx context.Context, args []string) error {
+	if len(args) < 1 {
+		return errUsage
+	}
+
+	switch args[0] {
+	case "list":
+		fs := flag.NewFlagSet("refuel list", flag.ContinueOnError)
+		pending := fs.Bool("pending", false, "только незавершённые")
+		status := fs.String("status", "", "статус: Created, Confirmed, PartiallyFulfilled, Cancelled")
+		grade := fs.String("grade", "", "марка топлива")
+		device := fs.String("device", "", "колонка")
+		from, to := periodFlags(fs)
+		if _, err := parseFlags(fs, args[1:]); err != nil {
+			return errUsage
+		}
+
+		var (
+			ops []entity.RefuelOperation
+			err error
+		)
+		switch {
+		case *pending:
+			ops, err = c.app.UseCase.GetPendingOperations(ctx, *device)
+		case *status != "" || *grade != "" || *device != "":
+			ops, err = c.app.UseCase.GetRefuelHistory(ctx, from.Time, to.Time, *device, *status, *grade)
+		default:
+			ops, err = c.app.UseCase.GetAllRefuel(ctx, from.Time, to.Time)
+		}
+		if err != nil {
+			return err
+		}
+		return c.out.refuels(ops)
+
+	case "show":
+		if len(args) != 2 {
+			return errUsage
+		}
+		op, err := c.app.UseCase.GetRefuelById(ctx, args[1])
+		if err != nil {
+			return err
+		}
+		return c.out.refuels([]entity.RefuelOperation{op})
+
+	case "cancel":
+		fs := flag.NewFlagSet("refuel cancel", flag.ContinueOnError)
+		reason := fs.String("reason", "", "причина отмены")
+		pos, err := parseFlags(fs, args[1:])
+		if err != nil || len(pos) != 1 || *reason == "" {
+			return errUsage
+		}
+
+		op, err := c.app.UseCase.CancelRefuel(ctx, pos[0], *reason)
+		if err != nil {
+			return err
+		}
+		return c.out.refuels([]entity.RefuelOperation{op})
+
+	case "expire":
+		if len(args) != 1 {
+			return errUsage
+		}
+		result := c.app.UseCase.ExpirePendingOperations(ctx)
+		if err := c.out.sweep(result); err != nil {
+			return err
+		}
+		if result.Error != "" {
+			return errors.New(result.Error)
+		}
+		return nil
+
+	default:
+		return errUsage
+	}
+}
+
+// Команды refund
+func (c *cli) refund(ctx context.Context, args []string) error {
+	if len(args) < 1 {
+		return errUsage
+	}
+
+	switch args[0] {
+	case "issue":
+		fs := flag.NewFlagSet("refund issue", flag.ContinueOnError)
+		method := fs.String("method", "", "способ возврата: cash или card")
+		issuedBy := fs.String("by", "", "кто выдаёт возврат")
+		pos, err := parseFlags(fs, args[1:])
+		if err != nil || len(pos) != 2 || *method == "" || *issuedBy == "" {
+			return errUsage
+		}
+
+		amount, err := entity.ParseMoney(pos[1])
+		if err != nil {
+			return err
+		}
+
+		refund, err := c.app.UseCase.IssueRefund(ctx, pos[0], amount, *method, *issuedBy)
+		if err != nil {
+			return err
+		}
+		return c.out.refunds([]entity.Refund{refund})
+
+	case "list":
+		if len(args) != 2 {
+			return errUsage
+		}
+		refunds, err := c.app.UseCase.GetRefunds(ctx, args[1])
+		if err != nil {
+			return err
+		}
+		return c.out.refunds(refunds)
+
+	case "outstanding":
+		fs := flag.NewFlagSet("refund outstanding", flag.ContinueOnError)
+		device := fs.String("device", "", "колонка")
+		if _, err := parseFlags(fs, args[1:]); err != nil {
+			return errUsage
+		}
+
+		ops, err := c.app.UseCase.GetOutstandingRefunds(ctx, *device)
+		if err != nil {
+			return err
+		}
+		return c.out.refuels(ops)
+
+	default:
+		return errUsage
+	}
+}
+
+// Команды payment
+func (c *cli) payment(ctx context.Context, args []string) error {
+	if len(args) < 1 {
+		return errUsage
+	}
+
+	switch args[0] {
+	case "add":
+		fs := flag.NewFlagSet("payment add", flag.ContinueOnError)
+		method := fs.String("method", "", "способ оплаты: cash, card, sbp или fuelcard")
+		ref := fs.String("ref", "", "номер внешней транзакции")
+		pos, err := parseFlags(fs, args[1:])
+		if err != nil || len(pos) != 2 || *method == "" {
+			return errUsage
+		}
+
+		amount, err := entity.ParseMoney(pos[1])
+		if err != nil {
+			return err
+		}
+
+		payment, err := c.app.UseCase.AddPayment(ctx, pos[0], entity.Payment{Method: *method, Amount: amount, ExternalRef: ref})
+		if err != nil {
+			return err
+		}
+		return c.out.payments([]entity.Payment{payment})
+
+	case "list":
+		if len(args) != 2 {
+			return errUsage
+		}
+		payments, err := c.app.UseCase.GetPayments(ctx, args[1])
+		if err != nil {
+			return err
+		}
+		return c.out.payments(payments)
+
+	default:
+		return errUsage
+	}
+}
+
+// Команды shift
+func (c *cli) shift(ctx context.Context, args []string) error {
+	if len(args) < 1 {
+		return errUsage
+	}
+
+	switch args[0] {
+	case "open":
+		fs := flag.NewFlagSet("shift open", flag.ContinueOnError)
+		operator := fs.String("operator", "", "оператор смены")
+		var cash entity.Money
+		fs.Var((*moneyFlag)(&cash), "cash", "наличные в кассе на открытии, ₽")
+		pos, err := parseFlags(fs, args[1:])
+		if err != nil || len(pos) != 0 || *operator == "" {
+			return errUsage
+		}
+
+		shift, err := c.app.UseCase.OpenShift(ctx, *operator, cash)
+		if err != nil {
+			return err
+		}
+		return c.out.shifts([]entity.Shift{shift})
+
+	case "close":
+		fs := flag.NewFlagSet("shift close", flag.ContinueOnError)
+		var cash entity.Money
+		fs.Var((*moneyFlag)(&cash), "cash", "наличные в кассе на закрытии, ₽")
+		pos, err := parseFlags(fs, args[1:])
+		if err != nil || len(pos) != 0 {
+			return errUsage
+		}
+
+		report, err := c.app.UseCase.CloseShift(ctx, cash)
+		if err != nil {
+			return err
+		}
+		return c.out.shiftReport(report)
+
+	case "current":
+		if len(args) != 1 {
+			return errUsage
+		}
+		report, err := c.app.UseCase.GetCurrentShift(ctx)
+		if err != nil {
+			return err
+		}
+		return c.out.shiftReport(report)
+
+	case "report":
+		if len(args) != 2 {
+			return errUsage
+		}
+		report, err := c.app.UseCase.GetShiftReport(ctx, args[1])
+		if err != nil {
+			return err
+		}
+		return c.out.shiftReport(report)
+
+	case "list":
+		fs := flag.NewFlagSet("shift list", flag.ContinueOnError)
+		operator := fs.String("operator", "", "оператор")
+		from, to := periodFlags(fs)
+		if _, err := parseFlags(fs, args[1:]); err != nil {
+			return errUsage
+		}
+
+		shifts, err := c.app.UseCase.GetShifts(ctx, from.Time, to.Time, *operator)
+		if err != nil {
+			return err
+		}
+		return c.out.shifts(shifts)
+
+	default:
+		return errUsage
+	}
+}
+
+// Команда stats
+func (c *cli) stats(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("stats", flag.ContinueOnError)
+	from, to := periodFlags(fs)
+	if _, err := parseFlags(fs, args); err != nil {
+		return errUsage
+	}
+
+	stats, err := c.app.UseCase.GetStatistics(ctx, from.Time, to.Time)
+	if err != nil {
+		return err
+	}
+	return c.out.statistics(stats)
+}
+
+// Команды limits
+func (c *cli) limits(ctx context.Context, args []string) error {
+	if len(args) < 1 {
+		return errUsage
+	}
+
+	switch args[0] {
+	case "show":
+		limits, err := c.app.UseCase.GetLimits(ctx)
+		if err != nil {
+			return err
+		}
+		return c.out.limits(limits)
+
+	case "set":
+		limits, err := c.app.UseCase.GetLimits(ctx)
+		if err != nil {
+			return err
+		}
+
+		fs := flag.NewFlagSet("limits set", flag.ContinueOnError)
+		fs.Var((*moneyFlag)(&limits.MinPricePerLiter), "min-price", "минимальная цена за литр, ₽")
+		fs.Var((*moneyFlag)(&limits.MaxPricePerLiter), "max-price", "максимальная цена за литр, ₽")
+		fs.Var((*moneyFlag)(&limits.MinAmountPaid), "min-amount", "минимальная сумма оплаты, ₽")
+		fs.Var((*moneyFlag)(&limits.MaxAmountPaid), "max-amount", "максимальная сумма оплаты, ₽")
+		fs.Int64Var(&limits.MaxPriceChangePercent, "max-change-percent", limits.MaxPriceChangePercent, "максимальное изменение цены за раз, % (0 — без ограничения)")
+		pos, err := parseFlags(fs, args[1:])
+		if err != nil || len(pos) != 0 || fs.NFlag() == 0 {
+			return errUsage
+		}
+
+		limits, err = c.app.UseCase.UpdateLimits(ctx, limits)
+		if err != nil {
+			return err
+		}
+		return c.out.limits(limits)
+
+	default:
+		return errUsage
+	}
+}
+
+// Разбирает флаги, которые могут идти и до, и после позиционных аргументов
+func parseFlags(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// Флаг с датой
+type timeFlag struct {
+	time.Time
+}
+
+func (t *timeFlag) String() string {
+	return t.Format(time.RFC3339)
+}
+
+func (t *timeFlag) Set(v string) error {
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04", "2006-01-02"} {
+		if parsed, err := time.ParseInLocation(layout, v, time.Local); err == nil {
+			t.Time = parsed
+			return nil
+		}
+	}
+	return fmt.Errorf("некорректная дата %q", v)
+}
+
+// Флаг с суммой в рублях
+type moneyFlag entity.Money
+
+func (m *moneyFlag) String() string {
+	return entity.Money(*m).String()
+}
+
+func (m *moneyFlag) Set(v string) error {
+	parsed, err := entity.ParseMoney(v)
+	if err != nil {
+		return err
+	}
+	*m = moneyFlag(parsed)
+	return nil
+}
+
+// Флаги --from/--to, по умолчанию последние сутки
+func periodFlags(fs *flag.FlagSet) (*timeFlag, *timeFlag) {
+	now := time.Now()
+	from := &timeFlag{now.Add(-24 * time.Hour)}
+	to := &timeFlag{now}
+	fs.Var(from, "from", "начало периода")
+	fs.Var(to, "to", "конец периода")
+	return from, to
+}
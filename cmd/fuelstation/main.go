@@ -0,0 +1,160 @@
+// Утилита оператора станции: цены, счётчик, заправки и статистика.
+//
+// Глобальные флаги: --json (вывод в JSON), --config FILE (файл конфигурации).
+//
+//	fuelstation [--json] grade list
+//	fuelstation [--json] grade add CODE NAME
+//	fuelstation [--json] price show [--grade GRADE]
+//	fuelstation [--json] price set GRADE PRICE [--init]
+//	fuelstation [--json] price history [--grade GRADE] [--from DATE] [--to DATE]
+//	fuelstation [--json] price intervals [--grade GRADE] [--from DATE] [--to DATE]
+//	fuelstation [--json] price at GRADE --at DATE
+//	fuelstation [--json] price schedule GRADE PRICE --at DATE
+//	fuelstation [--json] price scheduled [--grade GRADE] [--status STATUS]
+//	fuelstation [--json] price unschedule ID
+//	fuelstation [--json] device list
+//	fuelstation [--json] device add ID NAME
+//	fuelstation [--json] device enable|disable ID
+//	fuelstation [--json] counter show DEVICE
+//	fuelstation [--json] counter set DEVICE VALUE [--force]
+//	fuelstation [--json] refuel list [--pending] [--device DEVICE] [--status STATUS] [--grade GRADE] [--from DATE] [--to DATE]
+//	fuelstation [--json] refuel show ID
+//	fuelstation [--json] refuel cancel ID --reason REASON
+//	fuelstation [--json] refuel expire
+//	fuelstation [--json] refund issue OPERATION AMOUNT --method cash|card --by NAME
+//	fuelstation [--json] refund list OPERATION
+//	fuelstation [--json] refund outstanding [--device DEVICE]
+//	fuelstation [--json] payment add OPERATION AMOUNT --method cash|card|sbp|fuelcard [--ref REF]
+//	fuelstation [--json] payment list OPERATION
+//	fuelstation [--json] shift open --operator NAME [--cash AMOUNT]
+//	fuelstation [--json] shift close [--cash AMOUNT]
+//	fuelstation [--json] shift current
+//	fuelstation [--json] shift report ID
+//	fuelstation [--json] shift list [--operator NAME] [--from DATE] [--to DATE]
+//	fuelstation [--json] stats [--from DATE] [--to DATE]
+//	fuelstation [--json] limits show
+//	fuelstation [--json] limits set [--min-price] [--max-price] [--min-amount] [--max-amount] [--max-change-percent]
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"fuelStation/internal/adapter/repository"
+	"fuelStation/internal/app"
+	"fuelStation/internal/config"
+	"os"
+)
+
+var errUsage = errors.New(`использование: fuelstation [--json] [--config FILE] <команда>
+
+команды:
+  grade list                        марки топлива
+  grade add CODE NAME               добавить марку, например: grade add AI100 "АИ-100"
+  price show [--grade]              активные цены (всех марок или одной)
+  price set GRADE PRICE [--init]    новая цена марки за литр в рублях (--init для первой установки)
+  price history [--grade] [--from] [--to]
+                                    история цен
+  price intervals [--grade] [--from] [--to]
+                                    цены с периодами действия за промежуток
+  price at GRADE --at DATE          цена марки, действовавшая в указанный момент
+  price schedule GRADE PRICE --at DATE
+                                    запланировать цену марки с указанного момента
+  price scheduled [--grade] [--status]
+                                    запланированные цены (по умолчанию ожидающие)
+  price unschedule ID               отменить запланированную цену
+  device list                       колонки
+  device add ID NAME                зарегистрировать колонку, например: device add pump-2 "Колонка 2"
+  device enable|disable ID          включить/выключить колонку
+  counter show DEVICE               текущее значение счётчика колонки
+  counter set DEVICE VALUE [--force]
+                                    выставить счётчик колонки (--force без проверки на уменьшение)
+  refuel list [--pending] [--device] [--status] [--grade] [--from] [--to]
+  refuel show ID
+  refuel cancel ID --reason REASON
+  refuel expire                     отменить операции, не подтверждённые за refuels.pending_ttl
+  refund issue OPERATION AMOUNT --method cash|card --by NAME
+                                    выдать клиенту возврат по операции (сумма в рублях)
+  refund list OPERATION             возвраты по операции
+  refund outstanding [--device]     операции, по которым возврат ещё не выдан
+  payment add OPERATION AMOUNT --method cash|card|sbp|fuelcard [--ref REF]
+                                    принять платёж по операции (сумма в рублях)
+  payment list OPERATION            платежи по операции
+  shift open --operator NAME [--cash]
+                                    открыть смену (--cash — наличные в кассе, ₽)
+  shift close [--cash]              закрыть смену и вывести Z-отчёт (--cash — пересчитанные наличные, ₽)
+  shift current                     отчёт по открытой смене на текущий момент
+  shift report ID                   отчёт по смене
+  shift list [--operator] [--from] [--to]
+                                    смены за период
+  stats [--from] [--to]             статистика за период
+  limits show                       действующие лимиты цены и оплаты
+  limits set [--min-price] [--max-price] [--min-amount] [--max-amount] [--max-change-percent]
+                                    изменить лимиты (суммы в рублях, не указанные остаются прежними)
+
+даты: 2006-01-02 или 2006-01-02T15:04:05Z07:00, по умолчанию последние сутки`)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "❌", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	global := flag.NewFlagSet("fuelstation", flag.ContinueOnError)
+	jsonOut := global.Bool("json", false, "вывод в JSON")
+	configPath := global.String("config", "", "путь к файлу конфигурации")
+	if err := global.Parse(args); err != nil {
+		return errUsage
+	}
+
+	args = global.Args()
+	if len(args) < 1 {
+		return errUsage
+	}
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		return err
+	}
+
+	db, err := repository.OpenDatabase(cfg.RepositoryConfig())
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	c := &cli{
+		app: app.New(db, cfg.ServiceLimits(), cfg.Refuels.PendingTTL, cfg.Refuels.SweepInterval),
+		out: newPrinter(os.Stdout, *jsonOut),
+	}
+
+	ctx := context.Background()
+
+	switch args[0] {
+	case "grade":
+		return c.grade(ctx, args[1:])
+	case "price":
+		return c.price(ctx, args[1:])
+	case "device":
+		return c.device(ctx, args[1:])
+	case "counter":
+		return c.counter(ctx, args[1:])
+	case "refuel":
+		return c.refuel(ctx, args[1:])
+	case "refund":
+		return c.refund(ctx, args[1:])
+	case "payment":
+		return c.payment(ctx, args[1:])
+	case "shift":
+		return c.shift(ctx, args[1:])
+	case "stats":
+		return c.stats(ctx, args[1:])
+	case "limits":
+		return c.limits(ctx, args[1:])
+	default:
+		return errUsage
+	}
+}
@@ -0,0 +1,533 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"fuelStation/internal/domain/entity"
+	"fuelStation/internal/domain/service"
+	"io"
+	"text/tabwriter"
+	"time"
+)
+
+const timeLayout = "2006-01-02 15:04:05"
+
+// Вывод результатов таблицей или в JSON
+type printer struct {
+	w    io.Writer
+	json bool
+}
+
+func newPrinter(w io.Writer, asJSON bool) *printer {
+	return &printer{
+		w:    w,
+		json: asJSON,
+	}
+}
+
+func (p *printer) prices(prices []entity.FuelPrice) error {
+	if p.json {
+		type row struct {
+			ID            int64     `json:"id"`
+			Grade         string    `json:"grade"`
+			PricePerLiter string    `json:"price_per_liter"`
+			CreatedAt     time.Time `json:"created_at"`
+			IsActive      bool      `json:"is_active"`
+		}
+		rows := make([]row, 0, len(prices))
+		for _, pr := range prices {
+			rows = append(rows, row{pr.ID, pr.GradeCode, pr.PricePerLiter.String(), pr.CreatedAt, pr.IsActive})
+		}
+		return p.writeJSON(rows)
+	}
+
+	return p.table(func(tw *tabwriter.Writer) {
+		fmt.Fprintln(tw, "ID\tМАРКА\tЦЕНА, ₽/Л\tСОЗДАНА\tАКТИВНА")
+		for _, pr := range prices {
+			fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\n", pr.ID, pr.GradeCode, pr.PricePerLiter, pr.CreatedAt.Local().Format(timeLayout), yesNo(pr.IsActive))
+		}
+	})
+}
+
+func (p *printer) priceIntervals(intervals []entity.PriceInterval) error {
+	if p.json {
+		type row struct {
+			ID            int64      `json:"id"`
+			Grade         string     `json:"grade"`
+			PricePerLiter string     `json:"price_per_liter"`
+			EffectiveFrom time.Time  `json:"effective_from"`
+			EffectiveTo   *time.Time `json:"effective_to,omitempty"`
+		}
+		rows := make([]row, 0, len(intervals))
+		for _, i := range intervals {
+			rows = append(rows, row{i.Price.ID, i.Price.GradeCode, i.Price.PricePerLiter.String(), i.From, i.To})
+		}
+		return p.writeJSON(rows)
+	}
+
+	return p.table(func(tw *tabwriter.Writer) {
+		fmt.Fprintln(tw, "ID\tМАРКА\tЦЕНА, ₽/Л\tДЕЙСТВУЕТ С\tДЕЙСТВУЕТ ПО")
+		for _, i := range intervals {
+			to := "сейчас"
+			if i.To != nil {
+				to = i.To.Local().Format(timeLayout)
+			}
+			fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\n", i.Price.ID, i.Price.GradeCode, i.Price.PricePerLiter,
+				i.From.Local().Format(timeLayout), to)
+		}
+	})
+}
+
+func (p *printer) scheduledPrices(prices []entity.ScheduledPrice) error {
+	if p.json {
+		type row struct {
+			ID            int64      `json:"id"`
+			Grade         string     `json:"grade"`
+			PricePerLiter string     `json:"price_per_liter"`
+			EffectiveFrom time.Time  `json:"effective_from"`
+			Status        string     `json:"status"`
+			AppliedAt     *time.Time `json:"applied_at,omitempty"`
+			FailureReason *string    `json:"failure_reason,omitempty"`
+		}
+		rows := make([]row, 0, len(prices))
+		for _, pr := range prices {
+			rows = append(rows, row{pr.ID, pr.GradeCode, pr.PricePerLiter.String(), pr.EffectiveFrom, pr.Status, pr.AppliedAt, pr.FailureReason})
+		}
+		return p.writeJSON(rows)
+	}
+
+	return p.table(func(tw *tabwriter.Writer) {
+		fmt.Fprintln(tw, "ID\tМАРКА\tЦЕНА, ₽/Л\tДЕЙСТВУЕТ С\tСТАТУС\tПРИМЕНЕНА")
+		for _, pr := range prices {
+			applied := ""
+			switch {
+			case pr.AppliedAt != nil:
+				applied = pr.AppliedAt.Local().Format(timeLayout)
+			case pr.FailureReason != nil:
+				applied = "ошибка: " + *pr.FailureReason
+			}
+			fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%s\n", pr.ID, pr.GradeCode, pr.PricePerLiter,
+				pr.EffectiveFrom.Local().Format(timeLayout), pr.Status, applied)
+		}
+	})
+}
+
+func (p *printer) devices(devices []entity.Device) error {
+	if p.json {
+		return p.writeJSON(devices)
+	}
+
+	return p.table(func(tw *tabwriter.Writer) {
+		fmt.Fprintln(tw, "ID\tНАЗВАНИЕ\tВКЛЮЧЕНА\tДОБАВЛЕНА")
+		for _, d := range devices {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", d.ID, d.Name, yesNo(d.IsActive), d.CreatedAt.Local().Format(timeLayout))
+		}
+	})
+}
+
+func (p *printer) grades(grades []entity.FuelGrade) error {
+	if p.json {
+		return p.writeJSON(grades)
+	}
+
+	return p.table(func(tw *tabwriter.Writer) {
+		fmt.Fprintln(tw, "КОД\tНАЗВАНИЕ\tДОБАВЛЕНА")
+		for _, g := range grades {
+			fmt.Fprintf(tw, "%s\t%s\t%s\n", g.Code, g.Name, g.CreatedAt.Local().Format(timeLayout))
+		}
+	})
+}
+
+func (p *printer) counter(c entity.CounterState) error {
+	if p.json {
+		return p.writeJSON(struct {
+			DeviceID  string    `json:"device_id"`
+			Value     int64     `json:"value"`
+			Liters    string    `json:"liters"`
+			UpdatedAt time.Time `json:"updated_at"`
+			Version   int64     `json:"version"`
+		}{c.DeviceID, c.CurrentValue, entity.Volume(c.CurrentValue).String(), c.UpdatedAt, c.Version})
+	}
+
+	return p.table(func(tw *tabwriter.Writer) {
+		fmt.Fprintln(tw, "КОЛОНКА\tЗНАЧЕНИЕ\tЛИТРЫ\tОБНОВЛЁН\tВЕРСИЯ")
+		fmt.Fprintf(tw, "%s\t%d\t%s\t%s\t%d\n", c.DeviceID, c.CurrentValue, entity.Volume(c.CurrentValue), c.UpdatedAt.Local().Format(timeLayout), c.Version)
+	})
+}
+
+func (p *printer) refuels(ops []entity.RefuelOperation) error {
+	if p.json {
+		type row struct {
+			ID            int64      `json:"id"`
+			DeviceID      string     `json:"device_id"`
+			Grade         string     `json:"grade"`
+			Mode          string     `json:"mode"`
+			AmountPaid    string     `json:"amount_paid"`
+			Paid          string     `json:"paid"`
+			Liters        string     `json:"liters"`
+			Dispensed     *string    `json:"dispensed,omitempty"`
+			RefundDue     string     `json:"refund_due"`
+			Refunded      string     `json:"refunded"`
+			PricePerLiter string     `json:"price_per_liter"`
+			CounterBefore int64      `json:"counter_before"`
+			CounterAfter  int64      `json:"counter_after"`
+			Status        string     `json:"status"`
+			CreatedAt     time.Time  `json:"created_at"`
+			ConfirmedAt   *time.Time `json:"confirmed_at,omitempty"`
+			CancelledAt   *time.Time `json:"cancelled_at,omitempty"`
+			CancelReason  *string    `json:"cancel_reason,omitempty"`
+		}
+		rows := make([]row, 0, len(ops))
+		for _, o := range ops {
+			var dispensed *string
+			if o.DispensedLiters != nil {
+				v := o.DispensedLiters.String()
+				dispensed = &v
+			}
+			rows = append(rows, row{
+				o.ID, o.DeviceID, o.GradeCode, o.Mode, o.AmountPaid.String(), o.Paid.String(), o.CalculatedLiters.String(), dispensed, o.RefundDue.String(), o.Refunded.String(), o.PricePerLiter.String(),
+				o.CounterBefore, o.CounterAfter, o.Status, o.CreatedAt, o.ConfirmedAt, o.CancelledAt, o.CancelReason,
+			})
+		}
+		return p.writeJSON(rows)
+	}
+
+	return p.table(func(tw *tabwriter.Writer) {
+		fmt.Fprintln(tw, "ID\tКОЛОНКА\tМАРКА\tРЕЖИМ\tСУММА, ₽\tЛИТРЫ\tЦЕНА, ₽/Л\tСЧЁТЧИК\tСТАТУС\tК ВОЗВРАТУ, ₽\tСОЗДАНА\tПРИЧИНА ОТМЕНЫ")
+		for _, o := range ops {
+			reason := ""
+			if o.CancelReason != nil {
+				reason = *o.CancelReason
+			}
+			// Для частично выполненной операции — отпущено из рассчитанного
+			liters := o.CalculatedLiters.String()
+			if o.DispensedLiters != nil && *o.DispensedLiters != o.CalculatedLiters {
+				liters = o.DispensedLiters.String() + "/" + liters
+			}
+			fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%s\t%s\t%d→%d\t%s\t%s\t%s\t%s\n",
+				o.ID, o.DeviceID, o.GradeCode, o.Mode, o.AmountPaid, liters, o.PricePerLiter,
+				o.CounterBefore, o.CounterAfter, o.Status, o.RefundDue, o.CreatedAt.Local().Format(timeLayout), reason)
+		}
+	})
+}
+
+func (p *printer) refunds(refunds []entity.Refund) error {
+	if p.json {
+		type row struct {
+			ID          int64     `json:"id"`
+			OperationID int64     `json:"operation_id"`
+			Amount      string    `json:"amount"`
+			Method      string    `json:"method"`
+			IssuedBy    string    `json:"issued_by"`
+			IssuedAt    time.Time `json:"issued_at"`
+		}
+		rows := make([]row, 0, len(refunds))
+		for _, r := range refunds {
+			rows = append(rows, row{r.ID, r.OperationID, r.Amount.String(), r.Method, r.IssuedBy, r.IssuedAt})
+		}
+		return p.writeJSON(rows)
+	}
+
+	return p.table(func(tw *tabwriter.Writer) {
+		fmt.Fprintln(tw, "ID\tОПЕРАЦИЯ\tСУММА, ₽\tСПОСОБ\tВЫДАЛ\tКОГДА")
+		for _, r := range refunds {
+			fmt.Fprintf(tw, "%d\t%d\t%s\t%s\t%s\t%s\n", r.ID, r.OperationID, r.Amount, r.Method, r.IssuedBy, r.IssuedAt.Local().Format(timeLayout))
+		}
+	})
+}
+
+func (p *printer) payments(payments []entity.Payment) error {
+	if p.json {
+		type row struct {
+			ID          int64     `json:"id"`
+			OperationID int64     `json:"operation_id"`
+			Method      string    `json:"method"`
+			Amount      string    `json:"amount"`
+			ExternalRef *string   `json:"external_ref,omitempty"`
+			CreatedAt   time.Time `json:"created_at"`
+		}
+		rows := make([]row, 0, len(payments))
+		for _, pm := range payments {
+			rows = append(rows, row{pm.ID, pm.OperationID, pm.Method, pm.Amount.String(), pm.ExternalRef, pm.CreatedAt})
+		}
+		return p.writeJSON(rows)
+	}
+
+	return p.table(func(tw *tabwriter.Writer) {
+		fmt.Fprintln(tw, "ID\tОПЕРАЦИЯ\tСПОСОБ\tСУММА, ₽\tТРАНЗАКЦИЯ\tКОГДА")
+		for _, pm := range payments {
+			ref := ""
+			if pm.ExternalRef != nil {
+				ref = *pm.ExternalRef
+			}
+			fmt.Fprintf(tw, "%d\t%d\t%s\t%s\t%s\t%s\n", pm.ID, pm.OperationID, pm.Method, pm.Amount, ref, pm.CreatedAt.Local().Format(timeLayout))
+		}
+	})
+}
+
+func (p *printer) statistics(s service.RefuelStatistics) error {
+	if p.json {
+		return p.writeJSON(statisticsRow(s))
+	}
+
+	return p.table(func(tw *tabwriter.Writer) {
+		statisticsTable(tw, s)
+	})
+}
+
+type statsRow struct {
+	From            time.Time   `json:"from"`
+	To              time.Time   `json:"to"`
+	TotalOperations int64       `json:"total_operations"`
+	ConfirmedCount  int64       `json:"confirmed_count"`
+	PartialCount    int64       `json:"partially_fulfilled_count"`
+	CancelledCount  int64       `json:"cancelled_count"`
+	PendingCount    int64       `json:"pending_count"`
+	TotalRevenue    string      `json:"total_revenue"`
+	TotalRefunded   string      `json:"total_refunded"`
+	Outstanding     string      `json:"outstanding_refunds"`
+	TotalLiters     string      `json:"total_liters"`
+	AverageAmount   string      `json:"average_amount"`
+	AverageLiters   string      `json:"average_liters"`
+	ByGrade         []gradeRow  `json:"by_grade"`
+	ByPayment       []methodRow `json:"by_payment_method"`
+	Unallocated     string      `json:"unallocated"`
+}
+
+func statisticsRow(s service.RefuelStatistics) statsRow {
+	return statsRow{
+		s.StartDate, s.EndDate, s.TotalOperations, s.ConfirmedCount, s.PartialCount, s.CancelledCount, s.PendingCount,
+		s.TotalRevenue.String(), s.TotalRefunded.String(), s.OutstandingRefunds.String(), s.TotalLiters.String(), s.AverageAmount.String(), s.AverageLiters.String(),
+		gradeRows(s.ByGrade), methodRows(s.ByPaymentMethod), s.Unallocated.String(),
+	}
+}
+
+func statisticsTable(tw *tabwriter.Writer, s service.RefuelStatistics) {
+	fmt.Fprintf(tw, "Период\t%s — %s\n", s.StartDate.Local().Format(timeLayout), s.EndDate.Local().Format(timeLayout))
+	fmt.Fprintf(tw, "Всего операций\t%d\n", s.TotalOperations)
+	fmt.Fprintf(tw, "Подтверждено\t%d\n", s.ConfirmedCount)
+	fmt.Fprintf(tw, "  из них частично\t%d\n", s.PartialCount)
+	fmt.Fprintf(tw, "Отменено\t%d\n", s.CancelledCount)
+	fmt.Fprintf(tw, "Ожидают\t%d\n", s.PendingCount)
+	fmt.Fprintf(tw, "Выручка, ₽\t%s\n", s.TotalRevenue)
+	fmt.Fprintf(tw, "Возвраты выданы, ₽\t%s\n", s.TotalRefunded)
+	fmt.Fprintf(tw, "Возвраты к выдаче, ₽\t%s\n", s.OutstandingRefunds)
+	fmt.Fprintf(tw, "Литры\t%s\n", s.TotalLiters)
+	fmt.Fprintf(tw, "Средний чек, ₽\t%s\n", s.AverageAmount)
+	fmt.Fprintf(tw, "Средняя заправка, л\t%s\n", s.AverageLiters)
+	for _, g := range s.ByGrade {
+		fmt.Fprintf(tw, "%s\t%d оп., %s л, %s ₽\n", g.GradeCode, g.ConfirmedCount, g.TotalLiters, g.TotalRevenue)
+	}
+	for _, m := range s.ByPaymentMethod {
+		fmt.Fprintf(tw, "%s\t%d пл., принято %s ₽, возвращено %s ₽, итого %s ₽\n", m.Method, m.PaymentCount, m.Paid, m.Refunded, m.Net)
+	}
+	fmt.Fprintf(tw, "Не разнесено по платежам, ₽\t%s\n", s.Unallocated)
+}
+
+type gradeRow struct {
+	Grade          string `json:"grade"`
+	ConfirmedCount int64  `json:"confirmed_count"`
+	TotalRevenue   string `json:"total_revenue"`
+	TotalLiters    string `json:"total_liters"`
+}
+
+func gradeRows(grades []service.GradeStatistics) []gradeRow {
+	rows := make([]gradeRow, 0, len(grades))
+	for _, g := range grades {
+		rows = append(rows, gradeRow{g.GradeCode, g.ConfirmedCount, g.TotalRevenue.String(), g.TotalLiters.String()})
+	}
+	return rows
+}
+
+type methodRow struct {
+	Method       string `json:"method"`
+	PaymentCount int64  `json:"payment_count"`
+	Paid         string `json:"paid"`
+	Refunded     string `json:"refunded"`
+	Net          string `json:"net"`
+}
+
+func methodRows(methods []service.PaymentMethodStatistics) []methodRow {
+	rows := make([]methodRow, 0, len(methods))
+	for _, m := range methods {
+		rows = append(rows, methodRow{m.Method, m.PaymentCount, m.Paid.String(), m.Refunded.String(), m.Net.String()})
+	}
+	return rows
+}
+
+func (p *printer) shifts(shifts []entity.Shift) error {
+	if p.json {
+		type row struct {
+			ID          int64      `json:"id"`
+			Operator    string     `json:"operator"`
+			OpenedAt    time.Time  `json:"opened_at"`
+			ClosedAt    *time.Time `json:"closed_at,omitempty"`
+			OpeningCash string     `json:"opening_cash"`
+			ClosingCash *string    `json:"closing_cash,omitempty"`
+		}
+		rows := make([]row, 0, len(shifts))
+		for _, sh := range shifts {
+			var closing *string
+			if sh.ClosingCash != nil {
+				v := sh.ClosingCash.String()
+				closing = &v
+			}
+			rows = append(rows, row{sh.ID, sh.Operator, sh.OpenedAt, sh.ClosedAt, sh.OpeningCash.String(), closing})
+		}
+		return p.writeJSON(rows)
+	}
+
+	return p.table(func(tw *tabwriter.Writer) {
+		fmt.Fprintln(tw, "ID\tОПЕРАТОР\tОТКРЫТА\tЗАКРЫТА\tКАССА НА ОТКРЫТИИ, ₽\tКАССА НА ЗАКРЫТИИ, ₽")
+		for _, sh := range shifts {
+			closedAt, closing := "", ""
+			if sh.ClosedAt != nil {
+				closedAt = sh.ClosedAt.Local().Format(timeLayout)
+			}
+			if sh.ClosingCash != nil {
+				closing = sh.ClosingCash.String()
+			}
+			fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%s\n", sh.ID, sh.Operator, sh.OpenedAt.Local().Format(timeLayout), closedAt, sh.OpeningCash, closing)
+		}
+	})
+}
+
+func (p *printer) shiftReport(r service.ShiftReport) error {
+	if p.json {
+		type deviceRow struct {
+			DeviceID     string `json:"device_id"`
+			CounterStart int64  `json:"counter_start"`
+			CounterEnd   int64  `json:"counter_end"`
+			CounterDelta string `json:"counter_delta"`
+			SoldLiters   string `json:"sold_liters"`
+			Discrepancy  string `json:"discrepancy"`
+		}
+		devices := make([]deviceRow, 0, len(r.Devices))
+		for _, d := range r.Devices {
+			devices = append(devices, deviceRow{d.DeviceID, d.CounterStart, d.CounterEnd, d.CounterDelta.String(), d.SoldLiters.String(), d.Discrepancy.String()})
+		}
+		var cashDiscrepancy *string
+		if r.CashDiscrepancy != nil {
+			v := r.CashDiscrepancy.String()
+			cashDiscrepancy = &v
+		}
+		return p.writeJSON(struct {
+			ShiftID         int64       `json:"shift_id"`
+			Operator        string      `json:"operator"`
+			OpenedAt        time.Time   `json:"opened_at"`
+			ClosedAt        *time.Time  `json:"closed_at,omitempty"`
+			Statistics      statsRow    `json:"statistics"`
+			Devices         []deviceRow `json:"devices"`
+			ByPayment       []methodRow `json:"by_payment_method"`
+			CounterDelta    string      `json:"counter_delta"`
+			SoldLiters      string      `json:"sold_liters"`
+			Discrepancy     string      `json:"discrepancy"`
+			ExpectedCash    string      `json:"expected_cash"`
+			CashDiscrepancy *string     `json:"cash_discrepancy,omitempty"`
+		}{
+			r.Shift.ID, r.Shift.Operator, r.Shift.OpenedAt, r.Shift.ClosedAt, statisticsRow(r.Statistics), devices, methodRows(r.ByPaymentMethod),
+			r.CounterDelta.String(), r.SoldLiters.String(), r.Discrepancy.String(), r.ExpectedCash.String(), cashDiscrepancy,
+		})
+	}
+
+	return p.table(func(tw *tabwriter.Writer) {
+		title := "Промежуточный отчёт"
+		if r.Shift.ClosedAt != nil {
+			title = "Z-отчёт"
+		}
+		fmt.Fprintf(tw, "%s\tсмена #%d, оператор %s\n", title, r.Shift.ID, r.Shift.Operator)
+		statisticsTable(tw, r.Statistics)
+		for _, d := range r.Devices {
+			fmt.Fprintf(tw, "%s\tсчётчик %d→%d: %s л, продано %s л, расхождение %s л\n",
+				d.DeviceID, d.CounterStart, d.CounterEnd, d.CounterDelta, d.SoldLiters, d.Discrepancy)
+		}
+		fmt.Fprintf(tw, "Расхождение счётчиков, л\t%s\n", r.Discrepancy)
+		fmt.Fprintln(tw, "Касса за смену\t")
+		for _, m := range r.ByPaymentMethod {
+			fmt.Fprintf(tw, "%s\t%d пл., принято %s ₽, возвращено %s ₽, итого %s ₽\n", m.Method, m.PaymentCount, m.Paid, m.Refunded, m.Net)
+		}
+		fmt.Fprintf(tw, "Наличные на открытии, ₽\t%s\n", r.Shift.OpeningCash)
+		fmt.Fprintf(tw, "Ожидается в кассе, ₽\t%s\n", r.ExpectedCash)
+		if r.Shift.ClosingCash != nil && r.CashDiscrepancy != nil {
+			fmt.Fprintf(tw, "В кассе на закрытии, ₽\t%s\n", r.Shift.ClosingCash)
+			fmt.Fprintf(tw, "Расхождение кассы, ₽\t%s\n", r.CashDiscrepancy)
+		}
+	})
+}
+
+func (p *printer) limits(l entity.Limits) error {
+	if p.json {
+		return p.writeJSON(struct {
+			MinPricePerLiter      string     `json:"min_price_per_liter"`
+			MaxPricePerLiter      string     `json:"max_price_per_liter"`
+			MinAmountPaid         string     `json:"min_amount_paid"`
+			MaxAmountPaid         string     `json:"max_amount_paid"`
+			MaxPriceChangePercent int64      `json:"max_price_change_percent"`
+			UpdatedAt             *time.Time `json:"updated_at,omitempty"`
+		}{
+			l.MinPricePerLiter.String(), l.MaxPricePerLiter.String(), l.MinAmountPaid.String(), l.MaxAmountPaid.String(),
+			l.MaxPriceChangePercent, optionalTime(l.UpdatedAt),
+		})
+	}
+
+	updated := "по умолчанию"
+	if !l.UpdatedAt.IsZero() {
+		updated = l.UpdatedAt.Local().Format(timeLayout)
+	}
+	return p.table(func(tw *tabwriter.Writer) {
+		fmt.Fprintf(tw, "Цена за литр, ₽\t%s — %s\n", l.MinPricePerLiter, l.MaxPricePerLiter)
+		fmt.Fprintf(tw, "Оплата, ₽\t%s — %s\n", l.MinAmountPaid, l.MaxAmountPaid)
+		fmt.Fprintf(tw, "Изменение цены, %%\t%d\n", l.MaxPriceChangePercent)
+		fmt.Fprintf(tw, "Изменены\t%s\n", updated)
+	})
+}
+
+func (p *printer) sweep(r service.SweepResult) error {
+	if p.json {
+		cancelled := r.Cancelled
+		if cancelled == nil {
+			cancelled = []int64{}
+		}
+		return p.writeJSON(struct {
+			PendingTTL   string  `json:"pending_ttl"`
+			CancelledIDs []int64 `json:"cancelled_ids"`
+			Failed       int     `json:"failed"`
+			Error        string  `json:"error,omitempty"`
+		}{r.PendingTTL.String(), cancelled, r.Failed, r.Error})
+	}
+
+	return p.table(func(tw *tabwriter.Writer) {
+		fmt.Fprintf(tw, "Срок ожидания\t%s\n", r.PendingTTL)
+		fmt.Fprintf(tw, "Отменено\t%d\n", len(r.Cancelled))
+		for _, id := range r.Cancelled {
+			fmt.Fprintf(tw, "\t#%d\n", id)
+		}
+		fmt.Fprintf(tw, "Не удалось отменить\t%d\n", r.Failed)
+	})
+}
+
+func (p *printer) table(fill func(tw *tabwriter.Writer)) error {
+	tw := tabwriter.NewWriter(p.w, 0, 0, 2, ' ', 0)
+	fill(tw)
+	return tw.Flush()
+}
+
+func (p *printer) writeJSON(v any) error {
+	enc := json.NewEncoder(p.w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "да"
+	}
+	return "нет"
+}
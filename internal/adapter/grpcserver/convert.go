@@ -0,0 +1,144 @@
+package grpcserver
+
+import (
+	pb "fuelStation/api/fuelstation/v1"
+	"fuelStation/internal/domain/entity"
+	"fuelStation/internal/domain/service"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func toPbRefuel(o entity.RefuelOperation) *pb.RefuelOperation {
+	res := &pb.RefuelOperation{
+		Id:               o.ID,
+		DeviceId:         o.DeviceID,
+		Grade:            o.GradeCode,
+		Mode:             o.Mode,
+		AmountPaidKop:    int64(o.AmountPaid),
+		PaidKop:          int64(o.Paid),
+		CalculatedVolume: int64(o.CalculatedLiters),
+		DispensedVolume:  (*int64)(o.DispensedLiters),
+		RefundDueKop:     int64(o.RefundDue),
+		RefundedKop:      int64(o.Refunded),
+		PricePerLiterKop: int64(o.PricePerLiter),
+		CounterBefore:    o.CounterBefore,
+		CounterAfter:     o.CounterAfter,
+		Status:           o.Status,
+		CreatedAt:        timestamppb.New(o.CreatedAt),
+		CancelReason:     o.CancelReason,
+		IdempotencyKey:   o.IdempotencyKey,
+	}
+	if o.CancelledAt != nil {
+		res.CancelledAt = timestamppb.New(*o.CancelledAt)
+	}
+	return res
+}
+
+func toPbPrice(p entity.FuelPrice) *pb.FuelPrice {
+	return &pb.FuelPrice{
+		Id:               p.ID,
+		Grade:            p.GradeCode,
+		PricePerLiterKop: int64(p.PricePerLiter),
+		CreatedAt:        timestamppb.New(p.CreatedAt),
+		IsActive:         p.IsActive,
+	}
+}
+
+func toPbCounter(c entity.CounterState) *pb.CounterState {
+	return &pb.CounterState{
+		DeviceId:  c.DeviceID,
+		Value:     c.CurrentValue,
+		UpdatedAt: timestamppb.New(c.UpdatedAt),
+		Version:   c.Version,
+	}
+}
+
+func toPbStatistics(s service.RefuelStatistics) *pb.Statistics {
+	return &pb.Statistics{
+		TotalOperations:         s.TotalOperations,
+		TotalRevenueKop:         int64(s.TotalRevenue),
+		TotalRefundedKop:        int64(s.TotalRefunded),
+		OutstandingRefundsKop:   int64(s.OutstandingRefunds),
+		TotalVolume:             int64(s.TotalLiters),
+		AverageVolume:           int64(s.AverageLiters),
+		AverageAmountKop:        int64(s.AverageAmount),
+		ConfirmedCount:          s.ConfirmedCount,
+		PartiallyFulfilledCount: s.PartialCount,
+		CancelledCount:          s.CancelledCount,
+		PendingCount:            s.PendingCount,
+		StartDate:               timestamppb.New(s.StartDate),
+		EndDate:                 timestamppb.New(s.EndDate),
+		ByGrade:                 toPbGradeStatistics(s.ByGrade),
+		ByPaymentMethod:         toPbPaymentMethodStatistics(s.ByPaymentMethod),
+		UnallocatedKop:          int64(s.Unallocated),
+	}
+}
+
+func toPbPaymentMethodStatistics(methods []service.PaymentMethodStatistics) []*pb.PaymentMethodStatistics {
+	res := make([]*pb.PaymentMethodStatistics, 0, len(methods))
+	for _, m := range methods {
+		res = append(res, &pb.PaymentMethodStatistics{
+			Method:       m.Method,
+			PaymentCount: m.PaymentCount,
+			PaidKop:      int64(m.Paid),
+			RefundedKop:  int64(m.Refunded),
+			NetKop:       int64(m.Net),
+		})
+	}
+	return res
+}
+
+func toPbPayment(p entity.Payment) *pb.Payment {
+	return &pb.Payment{
+		Id:          p.ID,
+		OperationId: p.OperationID,
+		Method:      p.Method,
+		AmountKop:   int64(p.Amount),
+		ExternalRef: p.ExternalRef,
+		CreatedAt:   timestamppb.New(p.CreatedAt),
+	}
+}
+
+func fromPbPayment(p *pb.PaymentLine) entity.Payment {
+	return entity.Payment{
+		Method:      p.GetMethod(),
+		Amount:      entity.Money(p.GetAmountKop()),
+		ExternalRef: p.ExternalRef,
+	}
+}
+
+func fromPbPayments(lines []*pb.PaymentLine) []entity.Payment {
+	res := make([]entity.Payment, 0, len(lines))
+	for _, p := range lines {
+		res = append(res, fromPbPayment(p))
+	}
+	return res
+}
+
+func toPbGradeStatistics(grades []service.GradeStatistics) []*pb.GradeStatistics {
+	res := make([]*pb.GradeStatistics, 0, len(grades))
+	for _, g := range grades {
+		res = append(res, &pb.GradeStatistics{
+			Grade:           g.GradeCode,
+			ConfirmedCount:  g.ConfirmedCount,
+			TotalRevenueKop: int64(g.TotalRevenue),
+			TotalVolume:     int64(g.TotalLiters),
+		})
+	}
+	return res
+}
+
+func toPbDevice(d entity.Device) *pb.Device {
+	return &pb.Device{
+		Id:       d.ID,
+		Name:     d.Name,
+		IsActive: d.IsActive,
+	}
+}
+
+func toPbGrade(g entity.FuelGrade) *pb.FuelGrade {
+	return &pb.FuelGrade{
+		Code: g.Code,
+		Name: g.Name,
+	}
+}
@@ -0,0 +1,99 @@
+package grpcserver
+
+import (
+	"errors"
+	"fuelStation/internal/domain/service"
+	"log"
+	"strconv"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Соответствие ошибок сервисов gRPC кодам
+var errorCodes = []struct {
+	err  error
+	code codes.Code
+}{
+	{service.ErrNotFoundOper, codes.NotFound},
+	{service.ErrNotFoundPrice, codes.NotFound},
+	{service.ErrNotFoundOldPrice, codes.NotFound},
+	{service.ErrNotFoundCounter, codes.NotFound},
+	{service.ErrNotFoundGrade, codes.NotFound},
+	{service.ErrNotFoundDevice, codes.NotFound},
+	{service.ErrNotFoundScheduledPrice, codes.NotFound},
+	{service.ErrNotFoundShift, codes.NotFound},
+
+	{service.ErrPriceCanNotBeNegative, codes.InvalidArgument},
+	{service.ErrPriceTooHigh, codes.InvalidArgument},
+	{service.ErrPriceTooLow, codes.InvalidArgument},
+	{service.ErrPriceChangeTooLarge, codes.InvalidArgument},
+	{service.ErrCounterCanNotBeNegative, codes.InvalidArgument},
+	{service.ErrNewValueCanNotBeSmallerThanOld, codes.InvalidArgument},
+	{service.ErrAmountCanNotBeNegative, codes.InvalidArgument},
+	{service.ErrAmountTooHigh, codes.InvalidArgument},
+	{service.ErrAmountTooLow, codes.InvalidArgument},
+	{service.ErrInvalidLimits, codes.InvalidArgument},
+	{service.ErrInvalidGrade, codes.InvalidArgument},
+	{service.ErrInvalidDevice, codes.InvalidArgument},
+	{service.ErrScheduleInPast, codes.InvalidArgument},
+	{service.ErrInvalidIdempotencyKey, codes.InvalidArgument},
+	{service.ErrDispensedMoreThanPaid, codes.InvalidArgument},
+	{service.ErrInvalidRefund, codes.InvalidArgument},
+	{service.ErrRefundExceedsDue, codes.InvalidArgument},
+	{service.ErrInvalidRefuelMode, codes.InvalidArgument},
+	{service.ErrVolumeMustBePositive, codes.InvalidArgument},
+	{service.ErrCounterReadingRequired, codes.InvalidArgument},
+	{service.ErrNothingDispensed, codes.InvalidArgument},
+	{service.ErrInvalidPayment, codes.InvalidArgument},
+	{service.ErrPaymentExceedsAmount, codes.InvalidArgument},
+	{service.ErrPaymentsMismatchAmount, codes.InvalidArgument},
+	{service.ErrInvalidShift, codes.InvalidArgument},
+
+	{service.ErrInvalidOperationStatus, codes.FailedPrecondition},
+	{service.ErrCounterWasChangedDuringRefuelCreation, codes.FailedPrecondition},
+	{service.ErrTryUseChangePrice, codes.AlreadyExists},
+	{service.ErrCounterVersionConflict, codes.Aborted},
+	{service.ErrGradeAlreadyExists, codes.AlreadyExists},
+	{service.ErrDeviceAlreadyExists, codes.AlreadyExists},
+	{service.ErrDeviceInactive, codes.FailedPrecondition},
+	{service.ErrScheduledPriceNotPending, codes.FailedPrecondition},
+	{service.ErrIdempotencyKeyMismatch, codes.AlreadyExists},
+	{service.ErrShiftAlreadyOpen, codes.FailedPrecondition},
+	{service.ErrNoOpenShift, codes.FailedPrecondition},
+}
+
+// Переводит ошибку сервиса в gRPC статус; текст внутренних ошибок наружу не отдаётся
+func toStatus(err error) error {
+	for _, e := range errorCodes {
+		if errors.Is(err, e.err) {
+			return withLimitInfo(status.New(e.code, err.Error()), err).Err()
+		}
+	}
+
+	log.Printf("❌ %v\n", err)
+	return status.Error(codes.Internal, "internal error")
+}
+
+// Для нарушений лимитов добавляет ErrorInfo с правилом и порогом
+func withLimitInfo(st *status.Status, err error) *status.Status {
+	var limitErr *service.LimitError
+	if !errors.As(err, &limitErr) {
+		return st
+	}
+
+	detailed, detailsErr := st.WithDetails(&errdetails.ErrorInfo{
+		Reason: limitErr.Rule,
+		Domain: "fuelstation",
+		Metadata: map[string]string{
+			"value":     strconv.FormatInt(limitErr.Value, 10),
+			"threshold": strconv.FormatInt(limitErr.Threshold, 10),
+			"unit":      limitErr.Unit,
+		},
+	})
+	if detailsErr != nil {
+		return st
+	}
+	return detailed
+}
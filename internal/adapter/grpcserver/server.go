@@ -0,0 +1,248 @@
+package grpcserver
+
+import (
+	"context"
+	pb "fuelStation/api/fuelstation/v1"
+	"fuelStation/internal/domain/entity"
+	"fuelStation/internal/domain/service"
+	"fuelStation/internal/usecase"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// Период статистики по умолчанию
+	defaultPeriod = 24 * time.Hour
+
+	// Как часто проверять новые незавершённые операции в режиме watch
+	pendingPollInterval = 2 * time.Second
+)
+
+// Реализация pb.FuelStationServiceServer поверх usecase.UseCase
+type Server struct {
+	pb.UnimplementedFuelStationServiceServer
+
+	uc *usecase.UseCase
+}
+
+func NewServer(uc *usecase.UseCase) *Server {
+	return &Server{
+		uc: uc,
+	}
+}
+
+// Регистрирует сервис на gRPC сервере
+func (s *Server) Register(srv *grpc.Server) {
+	pb.RegisterFuelStationServiceServer(srv, s)
+}
+
+func (s *Server) CreateRefuel(ctx context.Context, req *pb.CreateRefuelRequest) (*pb.RefuelOperation, error) {
+	mode, err := service.ParseRefuelMode(req.GetMode())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	var op entity.RefuelOperation
+	switch mode {
+	case service.RefuelModeVolume:
+		op, err = s.uc.CreateRefuelByVolume(ctx, req.GetDeviceId(), req.GetGrade(), entity.Volume(req.GetVolume()), int(req.GetCounterBefore()), req.GetIdempotencyKey(), fromPbPayments(req.GetPayments()))
+	case service.RefuelModeFillUp:
+		op, err = s.uc.CreateFillUp(ctx, req.GetDeviceId(), req.GetGrade(), int(req.GetCounterBefore()), req.GetIdempotencyKey())
+	default:
+		op, err = s.uc.CreateRefuel(ctx, req.GetDeviceId(), req.GetGrade(), entity.Money(req.GetAmountPaidKop()), int(req.GetCounterBefore()), req.GetIdempotencyKey(), fromPbPayments(req.GetPayments()))
+	}
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toPbRefuel(op), nil
+}
+
+func (s *Server) ConfirmRefuel(ctx context.Context, req *pb.ConfirmRefuelRequest) (*pb.RefuelOperation, error) {
+	var (
+		op  entity.RefuelOperation
+		err error
+	)
+	if req.CounterAfter != nil {
+		op, err = s.uc.ConfirmRefuelWithCounter(ctx, req.GetId(), int(req.GetCounterAfter()))
+	} else {
+		op, err = s.uc.ConfirmRefuel(ctx, req.GetId())
+	}
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toPbRefuel(op), nil
+}
+
+func (s *Server) AddPayment(ctx context.Context, req *pb.AddPaymentRequest) (*pb.Payment, error) {
+	payment, err := s.uc.AddPayment(ctx, req.GetOperationId(), fromPbPayment(req.GetPayment()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toPbPayment(payment), nil
+}
+
+func (s *Server) CancelRefuel(ctx context.Context, req *pb.CancelRefuelRequest) (*pb.RefuelOperation, error) {
+	op, err := s.uc.CancelRefuel(ctx, req.GetId(), req.GetReason())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toPbRefuel(op), nil
+}
+
+func (s *Server) GetRefuel(ctx context.Context, req *pb.GetRefuelRequest) (*pb.RefuelOperation, error) {
+	op, err := s.uc.GetRefuelById(ctx, req.GetId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toPbRefuel(op), nil
+}
+
+// Отдаёт незавершённые операции; в режиме watch продолжает присылать новые, пока клиент не отключится
+func (s *Server) StreamPendingOperations(req *pb.StreamPendingOperationsRequest, stream grpc.ServerStreamingServer[pb.RefuelOperation]) error {
+	ctx := stream.Context()
+	sent := make(map[int64]bool)
+
+	ticker := time.NewTicker(pendingPollInterval)
+	defer ticker.Stop()
+
+	for {
+		ops, err := s.uc.GetPendingOperations(ctx, req.GetDeviceId())
+		if err != nil {
+			return toStatus(err)
+		}
+
+		// Помним только операции, которые всё ещё ждут подтверждения:
+		// из Created операция не возвращается, так что остальные можно забыть
+		pending := make(map[int64]bool, len(ops))
+		for _, op := range ops {
+			pending[op.ID] = true
+			if sent[op.ID] {
+				continue
+			}
+			if err := stream.Send(toPbRefuel(op)); err != nil {
+				return err
+			}
+		}
+		sent = pending
+
+		if !req.GetWatch() {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Server) GetStatistics(ctx context.Context, req *pb.GetStatisticsRequest) (*pb.Statistics, error) {
+	to := time.Now()
+	from := to.Add(-defaultPeriod)
+	if req.GetFrom() != nil {
+		from = req.GetFrom().AsTime()
+	}
+	if req.GetTo() != nil {
+		to = req.GetTo().AsTime()
+	}
+	if from.After(to) {
+		return nil, status.Error(codes.InvalidArgument, "from must be before to")
+	}
+
+	stats, err := s.uc.GetStatistics(ctx, from, to)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toPbStatistics(stats), nil
+}
+
+func (s *Server) ListGrades(ctx context.Context, _ *pb.ListGradesRequest) (*pb.ListGradesResponse, error) {
+	grades, err := s.uc.GetFuelGrades(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	res := &pb.ListGradesResponse{Grades: make([]*pb.FuelGrade, 0, len(grades))}
+	for _, g := range grades {
+		res.Grades = append(res.Grades, toPbGrade(g))
+	}
+	return res, nil
+}
+
+func (s *Server) ListActivePrices(ctx context.Context, _ *pb.ListActivePricesRequest) (*pb.ListActivePricesResponse, error) {
+	prices, err := s.uc.GetActivePrices(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	res := &pb.ListActivePricesResponse{Prices: make([]*pb.FuelPrice, 0, len(prices))}
+	for _, p := range prices {
+		res.Prices = append(res.Prices, toPbPrice(p))
+	}
+	return res, nil
+}
+
+func (s *Server) GetPrice(ctx context.Context, req *pb.GetPriceRequest) (*pb.FuelPrice, error) {
+	price, err := s.uc.GetPricePerLiter(ctx, req.GetGrade())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toPbPrice(price), nil
+}
+
+func (s *Server) InitPrice(ctx context.Context, req *pb.SetPriceRequest) (*pb.FuelPrice, error) {
+	price, err := s.uc.InitPricePerLiter(ctx, req.GetGrade(), entity.Money(req.GetPricePerLiterKop()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toPbPrice(price), nil
+}
+
+func (s *Server) ChangePrice(ctx context.Context, req *pb.SetPriceRequest) (*pb.FuelPrice, error) {
+	price, err := s.uc.ChangePricePerLiter(ctx, req.GetGrade(), entity.Money(req.GetPricePerLiterKop()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toPbPrice(price), nil
+}
+
+func (s *Server) ListDevices(ctx context.Context, _ *pb.ListDevicesRequest) (*pb.ListDevicesResponse, error) {
+	devices, err := s.uc.GetDevices(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	res := &pb.ListDevicesResponse{Devices: make([]*pb.Device, 0, len(devices))}
+	for _, d := range devices {
+		res.Devices = append(res.Devices, toPbDevice(d))
+	}
+	return res, nil
+}
+
+func (s *Server) GetCounter(ctx context.Context, req *pb.GetCounterRequest) (*pb.CounterState, error) {
+	counter, err := s.uc.GetCurrent(ctx, req.GetDeviceId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toPbCounter(counter), nil
+}
+
+func (s *Server) UpdateCounter(ctx context.Context, req *pb.UpdateCounterRequest) (*pb.CounterState, error) {
+	var (
+		counter entity.CounterState
+		err     error
+	)
+	if req.GetForce() {
+		counter, err = s.uc.UpdateCounter(ctx, req.GetDeviceId(), int(req.GetValue()))
+	} else {
+		counter, err = s.uc.UpdateCounterDuringRefuel(ctx, req.GetDeviceId(), int(req.GetValue()))
+	}
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toPbCounter(counter), nil
+}
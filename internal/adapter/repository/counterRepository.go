@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"fuelStation/internal/domain/entity"
+	"fuelStation/internal/domain/service"
+)
+
+type CounterRepository struct {
+	db *sql.DB
+}
+
+func NewCounterRepository(db *sql.DB) *CounterRepository {
+	return &CounterRepository{
+		db: db,
+	}
+}
+
+// Текущее состояние счётчика колонки
+func (r *CounterRepository) GetCurrent(ctx context.Context, deviceID string) (entity.CounterState, error) {
+	var state entity.CounterState
+
+	err := conn(ctx, r.db).QueryRowContext(ctx,
+		"SELECT state_id, device_id, current_value, version, updated_at FROM counters WHERE device_id = $1",
+		deviceID,
+	).Scan(&state.Id, &state.DeviceID, &state.CurrentValue, &state.Version, &state.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.CounterState{}, service.ErrNotFoundCounter
+	}
+	if err != nil {
+		return entity.CounterState{}, fmt.Errorf("ошибка получения счётчика %s: %w", deviceID, err)
+	}
+
+	return state, nil
+}
+
+// Сохранить новое состояние счётчика колонки state.DeviceID: значение пишется в историю (counter_states)
+// и становится текущим в counters. state.Version — версия, от которой делалось изменение:
+// если в базе уже другая версия, возвращается ErrCounterVersionConflict
+func (r *CounterRepository) Save(ctx context.Context, state entity.CounterState) error {
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		newVersion := state.Version + 1
+
+		var stateID int64
+		err := tx.QueryRowContext(ctx,
+			`INSERT INTO counter_states (device_id, current_value, version, updated_at)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (device_id, version) WHERE version > 0 DO NOTHING
+			RETURNING id`,
+			state.DeviceID, state.CurrentValue, newVersion, state.UpdatedAt,
+		).Scan(&stateID)
+		if errors.Is(err, sql.ErrNoRows) {
+			// Эту версию уже записал кто-то другой
+			return service.ErrCounterVersionConflict
+		}
+		if err != nil {
+			return fmt.Errorf("ошибка записи истории счётчика: %w", err)
+		}
+
+		var res sql.Result
+		if state.Version == 0 {
+			// Первое значение счётчика
+			res, err = tx.ExecContext(ctx,
+				`INSERT INTO counters (device_id, state_id, current_value, version, updated_at)
+				VALUES ($1, $2, $3, 1, $4)
+				ON CONFLICT (device_id) DO NOTHING`,
+				state.DeviceID, stateID, state.CurrentValue, state.UpdatedAt,
+			)
+		} else {
+			res, err = tx.ExecContext(ctx,
+				`UPDATE counters SET state_id = $1, current_value = $2, updated_at = $3, version = version + 1
+				WHERE device_id = $4 AND version = $5`,
+				stateID, state.CurrentValue, state.UpdatedAt, state.DeviceID, state.Version,
+			)
+		}
+		if err != nil {
+			return fmt.Errorf("ошибка сохранения счётчика: %w", err)
+		}
+
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("ошибка сохранения счётчика: %w", err)
+		}
+		if affected == 0 {
+			return service.ErrCounterVersionConflict
+		}
+
+		return nil
+	})
+}
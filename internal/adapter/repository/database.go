@@ -3,10 +3,9 @@ package repository
 import (
 	"database/sql"
 	"fmt"
+	"io/fs"
 	"log"
-	"os"
-	"path/filepath"
-	"sort"
+	"strings"
 	"time"
 
 	_ "github.com/jackc/pgx/v5/stdlib"
@@ -19,12 +18,25 @@ type DatabaseConfig struct {
 	Password string
 	DBName   string
 	SSLMode  string
+
+	// Настройки пула соединений (0 — значение по умолчанию)
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
 }
 
+const (
+	defaultMaxOpenConns    = 25
+	defaultMaxIdleConns    = 5
+	defaultConnMaxLifetime = 5 * time.Minute
+)
+
 // Подключается к бд
 func newDatabaseConn(cfg DatabaseConfig) (*sql.DB, error) {
 	connStr := fmt.Sprintf(
-		"host=%s port=%d user=%s password=%s dbname=%s sslmode=%s", cfg.Host, cfg.Port, cfg.User, cfg.Password, cfg.DBName, cfg.SSLMode,
+		"host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
+		quoteConnValue(cfg.Host), cfg.Port, quoteConnValue(cfg.User), quoteConnValue(cfg.Password),
+		quoteConnValue(cfg.DBName), quoteConnValue(cfg.SSLMode),
 	)
 
 	db, err := sql.Open("pgx", connStr)
@@ -36,15 +48,28 @@ func newDatabaseConn(cfg DatabaseConfig) (*sql.DB, error) {
 		return nil, err
 	}
 
-	db.SetMaxOpenConns(25)
-	db.SetMaxIdleConns(5)
-	db.SetConnMaxLifetime(5 * time.Minute)
+	db.SetMaxOpenConns(valueOr(cfg.MaxOpenConns, defaultMaxOpenConns))
+	db.SetMaxIdleConns(valueOr(cfg.MaxIdleConns, defaultMaxIdleConns))
+	db.SetConnMaxLifetime(valueOr(cfg.ConnMaxLifetime, defaultConnMaxLifetime))
 
 	log.Println("✅ Database connected!")
 
 	return db, nil
 }
 
+// Экранирует значение для строки подключения (пароль из файла может содержать пробелы и кавычки)
+func quoteConnValue(v string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(v) + "'"
+}
+
+func valueOr[T comparable](v, def T) T {
+	var zero T
+	if v == zero {
+		return def
+	}
+	return v
+}
+
 // Проверяет наличие базы данных и при ее отсуствии создает
 func createDatabaseIfNotExists(cfg DatabaseConfig) error {
 	tempCfg := cfg
@@ -77,55 +102,25 @@ func createDatabaseIfNotExists(cfg DatabaseConfig) error {
 	return nil
 }
 
-func runMigrations(db *sql.DB, migrationsDir string) error {
-	files, err := os.ReadDir(migrationsDir)
-	if err != nil{
-		return fmt.Errorf("ошибка чтения папки миграций: %w", err)
-	}
-
-	var sqlFiles []string
-	for _, file := range files{
-		if filepath.Ext(file.Name()) == ".sql"{
-			sqlFiles = append(sqlFiles, file.Name())
-		}
-	}
-
-	sort.Strings(sqlFiles)
-
-	if len(sqlFiles) == 0 {
-		log.Println("⚠️ Миграции не найдены")
-		return nil
-	}
-
-	for _, fileName := range sqlFiles{
-		filePath := filepath.Join(migrationsDir, fileName)
-		content, err := os.ReadFile(filePath)
-		if err != nil{
-			return fmt.Errorf("ошибка чтения файла %s: %w", fileName, err)
-		}
-
-		if _, err := db.Exec(string(content)); err != nil{
-			return fmt.Errorf("ошибка выполнения %s: %w", fileName, err)
-		}
-
-		log.Printf("✅ Миграция %s выполнена\n", fileName)
-	}
-
-	return nil
-}
-
-// InitDatabase инициализирует БД: создаёт её, подключается и запускает миграции
-func InitDatabase(cfg DatabaseConfig, migrationDir string) (*sql.DB, error) {
+// OpenDatabase создаёт БД при необходимости и подключается к ней без запуска миграций
+func OpenDatabase(cfg DatabaseConfig) (*sql.DB, error) {
 	if err := createDatabaseIfNotExists(cfg); err != nil {
 		return nil, err
 	}
 
-	db, err := newDatabaseConn(cfg)
+	return newDatabaseConn(cfg)
+}
+
+// InitDatabase инициализирует БД: создаёт её, подключается и запускает миграции.
+// migrations — EmbeddedMigrations() или DirMigrations(dir)
+func InitDatabase(cfg DatabaseConfig, migrations fs.FS) (*sql.DB, error) {
+	db, err := OpenDatabase(cfg)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := runMigrations(db, migrationDir); err != nil {
+	if err := runMigrations(db, migrations); err != nil {
+		db.Close()
 		return nil, err
 	}
 
@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"fuelStation/internal/domain/entity"
+	"fuelStation/internal/domain/service"
+)
+
+type DeviceRepository struct {
+	db *sql.DB
+}
+
+func NewDeviceRepository(db *sql.DB) *DeviceRepository {
+	return &DeviceRepository{
+		db: db,
+	}
+}
+
+const deviceColumns = "id, name, is_active, created_at"
+
+// Все колонки
+func (r *DeviceRepository) List(ctx context.Context) ([]entity.Device, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx,
+		"SELECT "+deviceColumns+" FROM devices ORDER BY id",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения колонок: %w", err)
+	}
+	defer rows.Close()
+
+	var devices []entity.Device
+	for rows.Next() {
+		device, err := scanDevice(rows)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка чтения колонки: %w", err)
+		}
+		devices = append(devices, device)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка получения колонок: %w", err)
+	}
+
+	return devices, nil
+}
+
+// Колонка по ID
+func (r *DeviceRepository) GetByID(ctx context.Context, id string) (entity.Device, error) {
+	row := conn(ctx, r.db).QueryRowContext(ctx,
+		"SELECT "+deviceColumns+" FROM devices WHERE id = $1", id,
+	)
+
+	device, err := scanDevice(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.Device{}, service.ErrNotFoundDevice
+	}
+	if err != nil {
+		return entity.Device{}, fmt.Errorf("ошибка получения колонки %s: %w", id, err)
+	}
+
+	return device, nil
+}
+
+// Зарегистрировать колонку (занятый ID — ErrDeviceAlreadyExists)
+func (r *DeviceRepository) Create(ctx context.Context, device *entity.Device) error {
+	res, err := conn(ctx, r.db).ExecContext(ctx,
+		"INSERT INTO devices (id, name, is_active, created_at) VALUES ($1, $2, $3, $4) ON CONFLICT (id) DO NOTHING",
+		device.ID, device.Name, device.IsActive, device.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("ошибка создания колонки %s: %w", device.ID, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("ошибка создания колонки %s: %w", device.ID, err)
+	}
+	if affected == 0 {
+		return service.ErrDeviceAlreadyExists
+	}
+
+	return nil
+}
+
+// Включить/выключить колонку
+func (r *DeviceRepository) SetActive(ctx context.Context, id string, active bool) error {
+	res, err := conn(ctx, r.db).ExecContext(ctx,
+		"UPDATE devices SET is_active = $1 WHERE id = $2", active, id,
+	)
+	if err != nil {
+		return fmt.Errorf("ошибка изменения колонки %s: %w", id, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("ошибка изменения колонки %s: %w", id, err)
+	}
+	if affected == 0 {
+		return service.ErrNotFoundDevice
+	}
+
+	return nil
+}
+
+func scanDevice(row rowScanner) (entity.Device, error) {
+	var d entity.Device
+	err := row.Scan(&d.ID, &d.Name, &d.IsActive, &d.CreatedAt)
+	return d, err
+}
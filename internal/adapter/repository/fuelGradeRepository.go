@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"fuelStation/internal/domain/entity"
+	"fuelStation/internal/domain/service"
+)
+
+type FuelGradeRepository struct {
+	db *sql.DB
+}
+
+func NewFuelGradeRepository(db *sql.DB) *FuelGradeRepository {
+	return &FuelGradeRepository{
+		db: db,
+	}
+}
+
+// Все марки топлива
+func (r *FuelGradeRepository) List(ctx context.Context) ([]entity.FuelGrade, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx,
+		"SELECT code, name, created_at FROM fuel_grades ORDER BY code",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения марок топлива: %w", err)
+	}
+	defer rows.Close()
+
+	var grades []entity.FuelGrade
+	for rows.Next() {
+		var g entity.FuelGrade
+		if err := rows.Scan(&g.Code, &g.Name, &g.CreatedAt); err != nil {
+			return nil, fmt.Errorf("ошибка чтения марки топлива: %w", err)
+		}
+		grades = append(grades, g)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка получения марок топлива: %w", err)
+	}
+
+	return grades, nil
+}
+
+// Марка по коду
+func (r *FuelGradeRepository) GetByCode(ctx context.Context, code string) (entity.FuelGrade, error) {
+	var g entity.FuelGrade
+
+	err := conn(ctx, r.db).QueryRowContext(ctx,
+		"SELECT code, name, created_at FROM fuel_grades WHERE code = $1", code,
+	).Scan(&g.Code, &g.Name, &g.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.FuelGrade{}, service.ErrNotFoundGrade
+	}
+	if err != nil {
+		return entity.FuelGrade{}, fmt.Errorf("ошибка получения марки топлива %s: %w", code, err)
+	}
+
+	return g, nil
+}
+
+// Добавить марку (занятый код — ErrGradeAlreadyExists)
+func (r *FuelGradeRepository) Create(ctx context.Context, grade *entity.FuelGrade) error {
+	res, err := conn(ctx, r.db).ExecContext(ctx,
+		"INSERT INTO fuel_grades (code, name, created_at) VALUES ($1, $2, $3) ON CONFLICT (code) DO NOTHING",
+		grade.Code, grade.Name, grade.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("ошибка создания марки топлива %s: %w", grade.Code, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("ошибка создания марки топлива %s: %w", grade.Code, err)
+	}
+	if affected == 0 {
+		return service.ErrGradeAlreadyExists
+	}
+
+	return nil
+}
@@ -0,0 +1,238 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"fuelStation/internal/domain/entity"
+	"fuelStation/internal/domain/interfaces"
+	"fuelStation/internal/domain/service"
+	"strconv"
+	"time"
+)
+
+type FuelPriceRepository struct {
+	db *sql.DB
+}
+
+func NewFuelPriceRepository(db *sql.DB) *FuelPriceRepository {
+	return &FuelPriceRepository{
+		db: db,
+	}
+}
+
+const fuelPriceColumns = "id, grade_code, price_per_liter_kop, created_at, is_active"
+
+// Получить текущую активную цену марки
+func (r *FuelPriceRepository) GetActive(ctx context.Context, grade string) (entity.FuelPrice, error) {
+	row := conn(ctx, r.db).QueryRowContext(ctx,
+		"SELECT "+fuelPriceColumns+" FROM fuel_prices WHERE is_active AND grade_code = $1 ORDER BY created_at DESC LIMIT 1",
+		grade,
+	)
+
+	price, err := scanFuelPrice(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.FuelPrice{}, service.ErrNotFoundOldPrice
+	}
+	if err != nil {
+		return entity.FuelPrice{}, fmt.Errorf("ошибка получения активной цены %s: %w", grade, err)
+	}
+
+	return price, nil
+}
+
+// Активные цены всех марок
+func (r *FuelPriceRepository) ListActive(ctx context.Context) ([]entity.FuelPrice, error) {
+	active := true
+	return r.Find(ctx, interfaces.FuelPriceFilter{IsActive: &active})
+}
+
+// Создать цену, деактивировав предыдущую (если она есть)
+func (r *FuelPriceRepository) ChangePrice(ctx context.Context, price entity.FuelPrice) error {
+	return r.replaceActive(ctx, price, false)
+}
+
+// Универсальный поиск цен
+func (r *FuelPriceRepository) Find(ctx context.Context, filter interfaces.FuelPriceFilter) ([]entity.FuelPrice, error) {
+	var b queryBuilder
+
+	if filter.Grade != nil {
+		b.add("grade_code = $%d", *filter.Grade)
+	}
+	if filter.IsActive != nil {
+		b.add("is_active = $%d", *filter.IsActive)
+	}
+	if filter.DateFrom != nil {
+		b.add("created_at >= $%d", *filter.DateFrom)
+	}
+	if filter.DateTo != nil {
+		b.add("created_at <= $%d", *filter.DateTo)
+	}
+
+	query := "SELECT " + fuelPriceColumns + " FROM fuel_prices" + b.where() +
+		" ORDER BY created_at DESC, id DESC" + b.paginate(filter.Limit, filter.Offset)
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, b.args...)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка поиска цен: %w", err)
+	}
+	defer rows.Close()
+
+	var prices []entity.FuelPrice
+	for rows.Next() {
+		price, err := scanFuelPrice(rows)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка чтения цены: %w", err)
+		}
+		prices = append(prices, price)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка поиска цен: %w", err)
+	}
+
+	return prices, nil
+}
+
+// Получить цену по ID
+func (r *FuelPriceRepository) GetByID(ctx context.Context, id string) (entity.FuelPrice, error) {
+	priceID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return entity.FuelPrice{}, service.ErrNotFoundPrice
+	}
+
+	row := conn(ctx, r.db).QueryRowContext(ctx,
+		"SELECT "+fuelPriceColumns+" FROM fuel_prices WHERE id = $1", priceID,
+	)
+
+	price, err := scanFuelPrice(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.FuelPrice{}, service.ErrNotFoundPrice
+	}
+	if err != nil {
+		return entity.FuelPrice{}, fmt.Errorf("ошибка получения цены %s: %w", id, err)
+	}
+
+	return price, nil
+}
+
+// Деактивировать текущую цену и активировать новую (в одной транзакции)
+func (r *FuelPriceRepository) ActivateNewPrice(ctx context.Context, price entity.FuelPrice) error {
+	return r.replaceActive(ctx, price, true)
+}
+
+// Снимает активность со старой цены марки и вставляет новую.
+// Если requireOld, то при отсутствии активной цены возвращается ErrNotFoundOldPrice
+func (r *FuelPriceRepository) replaceActive(ctx context.Context, price entity.FuelPrice, requireOld bool) error {
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		res, err := tx.ExecContext(ctx,
+			"UPDATE fuel_prices SET is_active = false WHERE is_active AND grade_code = $1", price.GradeCode,
+		)
+		if err != nil {
+			return fmt.Errorf("ошибка деактивации цены: %w", err)
+		}
+
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("ошибка деактивации цены: %w", err)
+		}
+		if requireOld && affected == 0 {
+			return service.ErrNotFoundOldPrice
+		}
+
+		if _, err := tx.ExecContext(ctx,
+			"INSERT INTO fuel_prices (grade_code, price_per_liter_kop, created_at, is_active) VALUES ($1, $2, $3, true)",
+			price.GradeCode, int64(price.PricePerLiter), price.CreatedAt,
+		); err != nil {
+			return fmt.Errorf("ошибка сохранения цены: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// Цена марки, действовавшая в момент t: последняя созданная не позже t
+func (r *FuelPriceRepository) GetAt(ctx context.Context, grade string, t time.Time) (entity.FuelPrice, error) {
+	row := conn(ctx, r.db).QueryRowContext(ctx,
+		"SELECT "+fuelPriceColumns+` FROM fuel_prices
+		WHERE grade_code = $1 AND created_at <= $2
+		ORDER BY created_at DESC, id DESC
+		LIMIT 1`,
+		grade, t,
+	)
+
+	price, err := scanFuelPrice(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.FuelPrice{}, service.ErrNotFoundPrice
+	}
+	if err != nil {
+		return entity.FuelPrice{}, fmt.Errorf("ошибка получения цены %s на %s: %w", grade, t.Format(time.RFC3339), err)
+	}
+
+	return price, nil
+}
+
+// Цены с периодами действия: каждая действует до создания следующей цены той же марки.
+// DateFrom/DateTo отбирают периоды, пересекающиеся с промежутком, а не цены, созданные в нём
+func (r *FuelPriceRepository) FindIntervals(ctx context.Context, filter interfaces.FuelPriceFilter) ([]entity.PriceInterval, error) {
+	var b queryBuilder
+
+	if filter.Grade != nil {
+		b.add("grade_code = $%d", *filter.Grade)
+	}
+	if filter.IsActive != nil {
+		b.add("is_active = $%d", *filter.IsActive)
+	}
+	if filter.DateFrom != nil {
+		b.add("(effective_to IS NULL OR effective_to > $%d)", *filter.DateFrom)
+	}
+	if filter.DateTo != nil {
+		b.add("created_at <= $%d", *filter.DateTo)
+	}
+
+	query := `SELECT ` + fuelPriceColumns + `, effective_to FROM (
+			SELECT ` + fuelPriceColumns + `,
+				LEAD(created_at) OVER (PARTITION BY grade_code ORDER BY created_at, id) AS effective_to
+			FROM fuel_prices
+		) intervals` + b.where() +
+		" ORDER BY grade_code, created_at DESC, id DESC" + b.paginate(filter.Limit, filter.Offset)
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, b.args...)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка поиска периодов цен: %w", err)
+	}
+	defer rows.Close()
+
+	var intervals []entity.PriceInterval
+	for rows.Next() {
+		var (
+			p  entity.FuelPrice
+			to sql.NullTime
+		)
+		if err := rows.Scan(&p.ID, &p.GradeCode, &p.PricePerLiter, &p.CreatedAt, &p.IsActive, &to); err != nil {
+			return nil, fmt.Errorf("ошибка чтения периода цены: %w", err)
+		}
+
+		interval := entity.PriceInterval{Price: p, From: p.CreatedAt}
+		if to.Valid {
+			interval.To = &to.Time
+		}
+		intervals = append(intervals, interval)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка поиска периодов цен: %w", err)
+	}
+
+	return intervals, nil
+}
+
+// Общий интерфейс для *sql.Row и *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanFuelPrice(row rowScanner) (entity.FuelPrice, error) {
+	var p entity.FuelPrice
+	err := row.Scan(&p.ID, &p.GradeCode, &p.PricePerLiter, &p.CreatedAt, &p.IsActive)
+	return p, err
+}
@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"fuelStation/internal/domain/entity"
+	"fuelStation/internal/domain/service"
+)
+
+type LimitsRepository struct {
+	db *sql.DB
+}
+
+func NewLimitsRepository(db *sql.DB) *LimitsRepository {
+	return &LimitsRepository{
+		db: db,
+	}
+}
+
+// Текущие лимиты
+func (r *LimitsRepository) Get(ctx context.Context) (entity.Limits, error) {
+	var l entity.Limits
+
+	err := conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT min_price_per_liter_kop, max_price_per_liter_kop, min_amount_paid_kop, max_amount_paid_kop,
+			max_price_change_percent, updated_at
+		FROM validation_limits`,
+	).Scan(&l.MinPricePerLiter, &l.MaxPricePerLiter, &l.MinAmountPaid, &l.MaxAmountPaid,
+		&l.MaxPriceChangePercent, &l.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.Limits{}, service.ErrNotFoundLimits
+	}
+	if err != nil {
+		return entity.Limits{}, fmt.Errorf("ошибка получения лимитов: %w", err)
+	}
+
+	return l, nil
+}
+
+// Сохранить лимиты (создаёт или обновляет единственную запись)
+func (r *LimitsRepository) Save(ctx context.Context, l entity.Limits) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx,
+		`INSERT INTO validation_limits (id, min_price_per_liter_kop, max_price_per_liter_kop,
+			min_amount_paid_kop, max_amount_paid_kop, max_price_change_percent, updated_at)
+		VALUES (TRUE, $1, $2, $3, $4, $5, $6)
+		ON CONFLICT (id) DO UPDATE SET
+			min_price_per_liter_kop = EXCLUDED.min_price_per_liter_kop,
+			max_price_per_liter_kop = EXCLUDED.max_price_per_liter_kop,
+			min_amount_paid_kop = EXCLUDED.min_amount_paid_kop,
+			max_amount_paid_kop = EXCLUDED.max_amount_paid_kop,
+			max_price_change_percent = EXCLUDED.max_price_change_percent,
+			updated_at = EXCLUDED.updated_at`,
+		int64(l.MinPricePerLiter), int64(l.MaxPricePerLiter), int64(l.MinAmountPaid), int64(l.MaxAmountPaid),
+		l.MaxPriceChangePercent, l.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("ошибка сохранения лимитов: %w", err)
+	}
+
+	return nil
+}
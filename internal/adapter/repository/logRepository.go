@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"fuelStation/internal/domain/entity"
+	"fuelStation/internal/domain/interfaces"
+	"strconv"
+	"time"
+)
+
+type LogRepository struct {
+	db *sql.DB
+}
+
+func NewLogRepository(db *sql.DB) *LogRepository {
+	return &LogRepository{
+		db: db,
+	}
+}
+
+// Сохранить лог (ID проставляется базой)
+func (r *LogRepository) Create(ctx context.Context, record *entity.LogRecord) error {
+	if record.CreatedAt.IsZero() {
+		record.CreatedAt = time.Now()
+	}
+
+	var meta sql.NullString
+	if record.Meta != "" {
+		meta = sql.NullString{String: record.Meta, Valid: true}
+	}
+
+	var id int64
+	err := conn(ctx, r.db).QueryRowContext(ctx,
+		`INSERT INTO logs (device_id, level, event_type, message, meta, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id`,
+		record.DeviceID, record.Level, record.EventType, record.Message, meta, record.CreatedAt,
+	).Scan(&id)
+	if err != nil {
+		return fmt.Errorf("ошибка сохранения лога: %w", err)
+	}
+
+	record.ID = strconv.FormatInt(id, 10)
+	return nil
+}
+
+// Универсальный поиск логов
+func (r *LogRepository) Find(ctx context.Context, filter interfaces.LogFilter) ([]entity.LogRecord, error) {
+	var b queryBuilder
+
+	if filter.DeviceID != nil {
+		b.add("device_id = $%d", *filter.DeviceID)
+	}
+	if filter.Level != nil {
+		b.add("level = $%d", *filter.Level)
+	}
+	if filter.EventType != nil {
+		b.add("event_type = $%d", *filter.EventType)
+	}
+	if filter.DateFrom != nil {
+		b.add("created_at >= $%d", *filter.DateFrom)
+	}
+	if filter.DateTo != nil {
+		b.add("created_at <= $%d", *filter.DateTo)
+	}
+
+	query := "SELECT id, device_id, level, event_type, message, COALESCE(meta::text, ''), created_at FROM logs" +
+		b.where() + " ORDER BY created_at DESC, id DESC" + b.paginate(filter.Limit, filter.Offset)
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, b.args...)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка поиска логов: %w", err)
+	}
+	defer rows.Close()
+
+	var records []entity.LogRecord
+	for rows.Next() {
+		var (
+			rec entity.LogRecord
+			id  int64
+		)
+		if err := rows.Scan(&id, &rec.DeviceID, &rec.Level, &rec.EventType, &rec.Message, &rec.Meta, &rec.CreatedAt); err != nil {
+			return nil, fmt.Errorf("ошибка чтения лога: %w", err)
+		}
+		rec.ID = strconv.FormatInt(id, 10)
+		records = append(records, rec)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка поиска логов: %w", err)
+	}
+
+	return records, nil
+}
+
+// Удалить логи старше before
+func (r *LogRepository) DeleteOld(ctx context.Context, before time.Time) error {
+	if _, err := conn(ctx, r.db).ExecContext(ctx, "DELETE FROM logs WHERE created_at < $1", before); err != nil {
+		return fmt.Errorf("ошибка удаления старых логов: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,351 @@
+package repository
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Ключ advisory lock, под которым выполняются миграции
+const migrationLockKey = 726354019
+
+var (
+	ErrMigrationChecksumMismatch = errors.New("применённая миграция была изменена")
+	ErrMigrationNoDown           = errors.New("у миграции нет down файла")
+	ErrMigrationUnknownVersion   = errors.New("неизвестная версия миграции")
+)
+
+// Миграция: пара файлов NNN_name.up.sql / NNN_name.down.sql
+type migration struct {
+	Version  int64
+	Name     string
+	UpSQL    string
+	DownSQL  string
+	Checksum string // контрольная сумма up файла
+}
+
+// Состояние миграции для команды status
+type MigrationStatus struct {
+	Version   int64
+	Name      string
+	Applied   bool
+	AppliedAt *time.Time
+}
+
+// Применяет и откатывает миграции из fs.FS
+type Migrator struct {
+	db         *sql.DB
+	migrations []migration
+}
+
+func NewMigrator(db *sql.DB, migrations fs.FS) (*Migrator, error) {
+	files, err := loadMigrations(migrations)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{
+		db:         db,
+		migrations: files,
+	}, nil
+}
+
+// Читает .up.sql/.down.sql файлы и сортирует миграции по версии
+func loadMigrations(migrations fs.FS) ([]migration, error) {
+	files, err := fs.ReadDir(migrations, ".")
+	if err != nil {
+		return nil, fmt.Errorf("ошибка чтения папки миграций: %w", err)
+	}
+
+	byVersion := make(map[int64]*migration)
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+
+		var (
+			base string
+			up   bool
+		)
+		switch {
+		case strings.HasSuffix(file.Name(), ".up.sql"):
+			base, up = strings.TrimSuffix(file.Name(), ".up.sql"), true
+		case strings.HasSuffix(file.Name(), ".down.sql"):
+			base = strings.TrimSuffix(file.Name(), ".down.sql")
+		default:
+			continue
+		}
+
+		version, err := parseMigrationVersion(file.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &migration{Version: version, Name: base}
+			byVersion[version] = m
+		}
+		if m.Name != base {
+			return nil, fmt.Errorf("версия %d повторяется в %s и %s", version, m.Name, base)
+		}
+
+		content, err := fs.ReadFile(migrations, file.Name())
+		if err != nil {
+			return nil, fmt.Errorf("ошибка чтения файла %s: %w", file.Name(), err)
+		}
+
+		if up {
+			sum := sha256.Sum256(content)
+			m.UpSQL = string(content)
+			m.Checksum = hex.EncodeToString(sum[:])
+		} else {
+			m.DownSQL = string(content)
+		}
+	}
+
+	result := make([]migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Checksum == "" {
+			return nil, fmt.Errorf("для миграции %s нет up файла", m.Name)
+		}
+		result = append(result, *m)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Version < result[j].Version })
+	return result, nil
+}
+
+func parseMigrationVersion(name string) (int64, error) {
+	prefix, _, ok := strings.Cut(name, "_")
+	if !ok {
+		return 0, fmt.Errorf("имя миграции %s должно начинаться с версии: 001_name.up.sql", name)
+	}
+	version, err := strconv.ParseInt(prefix, 10, 64)
+	if err != nil || version <= 0 {
+		return 0, fmt.Errorf("некорректная версия в имени миграции %s", name)
+	}
+	return version, nil
+}
+
+// Создаёт таблицу учёта миграций
+func ensureMigrationsTable(ctx context.Context, conn *sql.Conn) error {
+	_, err := conn.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version    BIGINT      PRIMARY KEY,
+		name       TEXT        NOT NULL,
+		checksum   TEXT        NOT NULL,
+		applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+	)`)
+	if err != nil {
+		return fmt.Errorf("ошибка создания schema_migrations: %w", err)
+	}
+	return nil
+}
+
+// Применённая миграция
+type appliedMigration struct {
+	Checksum  string
+	AppliedAt time.Time
+}
+
+func appliedMigrations(ctx context.Context, conn *sql.Conn) (map[int64]appliedMigration, error) {
+	rows, err := conn.QueryContext(ctx, "SELECT version, checksum, applied_at FROM schema_migrations")
+	if err != nil {
+		return nil, fmt.Errorf("ошибка чтения schema_migrations: %w", err)
+	}
+	defer rows.Close()
+
+	applied := make(map[int64]appliedMigration)
+	for rows.Next() {
+		var (
+			version int64
+			a       appliedMigration
+		)
+		if err := rows.Scan(&version, &a.Checksum, &a.AppliedAt); err != nil {
+			return nil, fmt.Errorf("ошибка чтения schema_migrations: %w", err)
+		}
+		applied[version] = a
+	}
+	return applied, rows.Err()
+}
+
+// Выполняет fn на отдельном соединении под advisory lock,
+// чтобы несколько экземпляров не мигрировали базу одновременно
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn, applied map[int64]appliedMigration) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("ошибка получения соединения: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", migrationLockKey); err != nil {
+		return fmt.Errorf("ошибка блокировки миграций: %w", err)
+	}
+	defer conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", migrationLockKey)
+
+	if err := ensureMigrationsTable(ctx, conn); err != nil {
+		return err
+	}
+
+	applied, err := appliedMigrations(ctx, conn)
+	if err != nil {
+		return err
+	}
+
+	// Изменённая после применения миграция — ошибка, дальше не идём
+	for _, mig := range m.migrations {
+		if a, ok := applied[mig.Version]; ok && a.Checksum != mig.Checksum {
+			return fmt.Errorf("%w: %s", ErrMigrationChecksumMismatch, mig.Name)
+		}
+	}
+
+	return fn(conn, applied)
+}
+
+// Выполняет sql миграции и обновляет schema_migrations в одной транзакции
+func execMigration(ctx context.Context, conn *sql.Conn, mig migration, up bool) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("ошибка начала транзакции: %w", err)
+	}
+	defer tx.Rollback()
+
+	script := mig.UpSQL
+	if !up {
+		script = mig.DownSQL
+	}
+
+	if _, err := tx.ExecContext(ctx, script); err != nil {
+		return fmt.Errorf("ошибка выполнения %s: %w", mig.Name, err)
+	}
+
+	if up {
+		_, err = tx.ExecContext(ctx,
+			"INSERT INTO schema_migrations (version, name, checksum) VALUES ($1, $2, $3)",
+			mig.Version, mig.Name, mig.Checksum,
+		)
+	} else {
+		_, err = tx.ExecContext(ctx, "DELETE FROM schema_migrations WHERE version = $1", mig.Version)
+	}
+	if err != nil {
+		return fmt.Errorf("ошибка записи версии %s: %w", mig.Name, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("ошибка фиксации %s: %w", mig.Name, err)
+	}
+
+	if up {
+		log.Printf("✅ Миграция %s выполнена\n", mig.Name)
+	} else {
+		log.Printf("↩️ Миграция %s откачена\n", mig.Name)
+	}
+	return nil
+}
+
+// Применяет все ещё не выполненные миграции
+func (m *Migrator) Up(ctx context.Context) error {
+	if len(m.migrations) == 0 {
+		log.Println("⚠️ Миграции не найдены")
+		return nil
+	}
+	return m.Goto(ctx, m.migrations[len(m.migrations)-1].Version)
+}
+
+// Откатывает последние steps применённых миграций
+func (m *Migrator) Down(ctx context.Context, steps int) error {
+	return m.withLock(ctx, func(conn *sql.Conn, applied map[int64]appliedMigration) error {
+		for i := len(m.migrations) - 1; i >= 0 && steps > 0; i-- {
+			mig := m.migrations[i]
+			if _, ok := applied[mig.Version]; !ok {
+				continue
+			}
+			if err := m.down(ctx, conn, mig); err != nil {
+				return err
+			}
+			steps--
+		}
+		return nil
+	})
+}
+
+// Приводит базу к версии version: применяет миграции до неё и откатывает всё, что выше.
+// version = 0 откатывает все миграции
+func (m *Migrator) Goto(ctx context.Context, version int64) error {
+	if version != 0 && !m.known(version) {
+		return fmt.Errorf("%w: %d", ErrMigrationUnknownVersion, version)
+	}
+
+	return m.withLock(ctx, func(conn *sql.Conn, applied map[int64]appliedMigration) error {
+		for i := len(m.migrations) - 1; i >= 0; i-- {
+			mig := m.migrations[i]
+			if _, ok := applied[mig.Version]; ok && mig.Version > version {
+				if err := m.down(ctx, conn, mig); err != nil {
+					return err
+				}
+			}
+		}
+
+		for _, mig := range m.migrations {
+			if _, ok := applied[mig.Version]; !ok && mig.Version <= version {
+				if err := execMigration(ctx, conn, mig, true); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// Состояние всех известных миграций
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	var result []MigrationStatus
+
+	err := m.withLock(ctx, func(conn *sql.Conn, applied map[int64]appliedMigration) error {
+		for _, mig := range m.migrations {
+			st := MigrationStatus{Version: mig.Version, Name: mig.Name}
+			if a, ok := applied[mig.Version]; ok {
+				st.Applied = true
+				st.AppliedAt = &a.AppliedAt
+			}
+			result = append(result, st)
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+func (m *Migrator) down(ctx context.Context, conn *sql.Conn, mig migration) error {
+	if strings.TrimSpace(mig.DownSQL) == "" {
+		return fmt.Errorf("%w: %s", ErrMigrationNoDown, mig.Name)
+	}
+	return execMigration(ctx, conn, mig, false)
+}
+
+func (m *Migrator) known(version int64) bool {
+	for _, mig := range m.migrations {
+		if mig.Version == version {
+			return true
+		}
+	}
+	return false
+}
+
+// Применяет ещё не выполненные миграции (вызывается при старте)
+func runMigrations(db *sql.DB, migrations fs.FS) error {
+	migrator, err := NewMigrator(db, migrations)
+	if err != nil {
+		return err
+	}
+	return migrator.Up(context.Background())
+}
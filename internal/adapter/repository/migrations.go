@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"embed"
+	"io/fs"
+	"os"
+)
+
+//go:embed migrations/*.sql
+var embeddedMigrations embed.FS
+
+// Миграции, вшитые в бинарник
+func EmbeddedMigrations() fs.FS {
+	sub, err := fs.Sub(embeddedMigrations, "migrations")
+	if err != nil {
+		// Невозможно: каталог migrations вшит при сборке
+		panic(err)
+	}
+	return sub
+}
+
+// Миграции из каталога на диске (например, для отладки новых миграций без пересборки)
+func DirMigrations(dir string) fs.FS {
+	return os.DirFS(dir)
+}
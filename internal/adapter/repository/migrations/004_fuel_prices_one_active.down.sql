@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_fuel_prices_one_active;
+
+ALTER TABLE fuel_prices DROP CONSTRAINT IF EXISTS chk_fuel_prices_positive;
+ALTER TABLE fuel_prices ALTER COLUMN is_active SET DEFAULT TRUE;
@@ -0,0 +1,11 @@
+-- Оставляем активной только последнюю цену
+UPDATE fuel_prices SET is_active = FALSE
+WHERE is_active AND id <> (
+    SELECT id FROM fuel_prices WHERE is_active ORDER BY created_at DESC, id DESC LIMIT 1
+);
+
+ALTER TABLE fuel_prices ALTER COLUMN is_active SET DEFAULT FALSE;
+ALTER TABLE fuel_prices ADD CONSTRAINT chk_fuel_prices_positive CHECK (price_per_liter > 0);
+
+-- Активной может быть только одна цена
+CREATE UNIQUE INDEX IF NOT EXISTS idx_fuel_prices_one_active ON fuel_prices(is_active) WHERE is_active;
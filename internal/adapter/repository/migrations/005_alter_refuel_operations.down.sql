@@ -0,0 +1,13 @@
+DROP INDEX IF EXISTS idx_refuel_device_date;
+
+ALTER TABLE refuel_operations DROP CONSTRAINT IF EXISTS chk_refuel_counter_order;
+ALTER TABLE refuel_operations DROP CONSTRAINT IF EXISTS chk_refuel_amount_positive;
+ALTER TABLE refuel_operations DROP CONSTRAINT IF EXISTS chk_refuel_status;
+
+ALTER TABLE refuel_operations ALTER COLUMN status SET DEFAULT 'CREATED';
+UPDATE refuel_operations SET status = 'CREATED' WHERE status = 'Created';
+
+ALTER TABLE refuel_operations DROP COLUMN IF EXISTS device_id;
+
+-- NOT NULL для fuel_price_id и counter_state_id не возвращаем:
+-- у уже созданных операций этих ссылок нет
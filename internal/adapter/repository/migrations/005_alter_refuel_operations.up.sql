@@ -0,0 +1,16 @@
+-- Операция создаётся до того, как известны ссылки на цену и состояние счётчика
+ALTER TABLE refuel_operations ALTER COLUMN fuel_price_id DROP NOT NULL;
+ALTER TABLE refuel_operations ALTER COLUMN counter_state_id DROP NOT NULL;
+
+ALTER TABLE refuel_operations ADD COLUMN IF NOT EXISTS device_id TEXT;
+
+-- Статусы в том виде, в котором их пишет сервис
+UPDATE refuel_operations SET status = 'Created' WHERE status = 'CREATED';
+ALTER TABLE refuel_operations ALTER COLUMN status SET DEFAULT 'Created';
+ALTER TABLE refuel_operations ADD CONSTRAINT chk_refuel_status
+    CHECK (status IN ('Created', 'Confirmed', 'Cancelled'));
+
+ALTER TABLE refuel_operations ADD CONSTRAINT chk_refuel_amount_positive CHECK (amount_paid > 0);
+ALTER TABLE refuel_operations ADD CONSTRAINT chk_refuel_counter_order CHECK (counter_after >= counter_before);
+
+CREATE INDEX IF NOT EXISTS idx_refuel_device_date ON refuel_operations(device_id, created_at DESC);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS counters;
+
+DROP INDEX IF EXISTS idx_counter_states_device_version;
+ALTER TABLE counter_states DROP COLUMN IF EXISTS version;
+ALTER TABLE counter_states DROP COLUMN IF EXISTS device_id;
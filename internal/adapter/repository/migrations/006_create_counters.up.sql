@@ -0,0 +1,25 @@
+-- counter_states хранит историю всех значений счётчика
+ALTER TABLE counter_states ADD COLUMN IF NOT EXISTS device_id TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE counter_states ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 0;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_counter_states_device_version
+    ON counter_states(device_id, version) WHERE version > 0;
+
+-- Текущее показание: ровно одна строка на колонку
+CREATE TABLE IF NOT EXISTS counters(
+    device_id TEXT PRIMARY KEY,
+    state_id BIGINT NOT NULL REFERENCES counter_states(id),
+    current_value BIGINT NOT NULL CHECK (current_value >= 0),
+    version BIGINT NOT NULL DEFAULT 1,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Переносим последнее известное значение
+INSERT INTO counters (device_id, state_id, current_value, version, updated_at)
+SELECT 'default', id, current_value, 1, updated_at
+FROM counter_states
+ORDER BY updated_at DESC, id DESC
+LIMIT 1
+ON CONFLICT (device_id) DO NOTHING;
+
+UPDATE counter_states SET version = 1 WHERE id = (SELECT state_id FROM counters WHERE device_id = 'default');
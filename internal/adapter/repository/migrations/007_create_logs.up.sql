@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS logs(
+    id BIGSERIAL PRIMARY KEY,
+    device_id TEXT NOT NULL DEFAULT '',
+    level VARCHAR(20) NOT NULL,
+    event_type VARCHAR(50) NOT NULL,
+    message TEXT NOT NULL,
+    meta JSONB DEFAULT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- created_at нужен и для фильтра по датам, и для удаления старых логов
+CREATE INDEX IF NOT EXISTS idx_logs_created_at ON logs(created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_logs_device_date ON logs(device_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_logs_level_date ON logs(level, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_logs_event_type_date ON logs(event_type, created_at DESC);
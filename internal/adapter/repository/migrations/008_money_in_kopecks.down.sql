@@ -0,0 +1,13 @@
+ALTER TABLE refuel_operations DROP CONSTRAINT IF EXISTS chk_refuel_volume_matches_counter;
+
+ALTER TABLE refuel_operations RENAME COLUMN calculated_volume TO calculated_liters;
+ALTER TABLE refuel_operations ALTER COLUMN calculated_liters TYPE DECIMAL(10, 2) USING calculated_liters / 10.0;
+
+ALTER TABLE refuel_operations RENAME COLUMN price_per_liter_kop TO price_per_liter;
+ALTER TABLE refuel_operations ALTER COLUMN price_per_liter TYPE DECIMAL(10, 2) USING price_per_liter / 100.0;
+
+ALTER TABLE refuel_operations RENAME COLUMN amount_paid_kop TO amount_paid;
+ALTER TABLE refuel_operations ALTER COLUMN amount_paid TYPE DECIMAL(10, 2) USING amount_paid / 100.0;
+
+ALTER TABLE fuel_prices RENAME COLUMN price_per_liter_kop TO price_per_liter;
+ALTER TABLE fuel_prices ALTER COLUMN price_per_liter TYPE DECIMAL(10, 2) USING price_per_liter / 100.0;
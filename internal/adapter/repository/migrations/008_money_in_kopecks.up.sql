@@ -0,0 +1,15 @@
+-- Деньги храним в копейках, объём — в десятых литра (единицах счётчика)
+ALTER TABLE fuel_prices ALTER COLUMN price_per_liter TYPE BIGINT USING round(price_per_liter * 100);
+ALTER TABLE fuel_prices RENAME COLUMN price_per_liter TO price_per_liter_kop;
+
+ALTER TABLE refuel_operations ALTER COLUMN amount_paid TYPE BIGINT USING round(amount_paid * 100);
+ALTER TABLE refuel_operations RENAME COLUMN amount_paid TO amount_paid_kop;
+
+ALTER TABLE refuel_operations ALTER COLUMN price_per_liter TYPE BIGINT USING round(price_per_liter * 100);
+ALTER TABLE refuel_operations RENAME COLUMN price_per_liter TO price_per_liter_kop;
+
+-- Объём берём из сдвига счётчика, чтобы он точно сходился со счётчиком
+ALTER TABLE refuel_operations ALTER COLUMN calculated_liters TYPE BIGINT USING (counter_after - counter_before);
+ALTER TABLE refuel_operations RENAME COLUMN calculated_liters TO calculated_volume;
+ALTER TABLE refuel_operations ADD CONSTRAINT chk_refuel_volume_matches_counter
+    CHECK (calculated_volume = counter_after - counter_before);
@@ -0,0 +1,13 @@
+-- Лимиты проверки цены и оплаты: одна строка, меняется администратором
+CREATE TABLE IF NOT EXISTS validation_limits(
+    id BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (id),
+    min_price_per_liter_kop BIGINT NOT NULL DEFAULT 0 CHECK (min_price_per_liter_kop >= 0),
+    max_price_per_liter_kop BIGINT NOT NULL CHECK (max_price_per_liter_kop > 0),
+    min_amount_paid_kop BIGINT NOT NULL DEFAULT 0 CHECK (min_amount_paid_kop >= 0),
+    max_amount_paid_kop BIGINT NOT NULL CHECK (max_amount_paid_kop > 0),
+    max_price_change_percent BIGINT NOT NULL DEFAULT 0 CHECK (max_price_change_percent >= 0),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CHECK (min_price_per_liter_kop <= max_price_per_liter_kop),
+    CHECK (min_amount_paid_kop <= max_amount_paid_kop)
+);
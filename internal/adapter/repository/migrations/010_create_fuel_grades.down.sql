@@ -0,0 +1,15 @@
+DROP INDEX IF EXISTS idx_refuel_grade_date;
+DROP INDEX IF EXISTS idx_fuel_prices_grade_created_at;
+DROP INDEX IF EXISTS idx_fuel_prices_one_active_per_grade;
+
+-- Без марок активной может остаться только одна цена: последняя по времени
+UPDATE fuel_prices SET is_active = FALSE
+WHERE is_active AND id <> (
+    SELECT id FROM fuel_prices WHERE is_active ORDER BY created_at DESC, id DESC LIMIT 1
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_fuel_prices_one_active ON fuel_prices(is_active) WHERE is_active;
+
+ALTER TABLE refuel_operations DROP COLUMN IF EXISTS grade_code;
+ALTER TABLE fuel_prices DROP COLUMN IF EXISTS grade_code;
+
+DROP TABLE IF EXISTS fuel_grades;
@@ -0,0 +1,26 @@
+-- Марки топлива; у каждой своя активная цена
+CREATE TABLE IF NOT EXISTS fuel_grades(
+    code VARCHAR(16) PRIMARY KEY,
+    name TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO fuel_grades (code, name) VALUES
+    ('AI92', 'АИ-92'),
+    ('AI95', 'АИ-95'),
+    ('DT', 'ДТ')
+ON CONFLICT (code) DO NOTHING;
+
+-- Всё, что было до марок, продавалось как АИ-92
+ALTER TABLE fuel_prices ADD COLUMN IF NOT EXISTS grade_code VARCHAR(16) NOT NULL DEFAULT 'AI92' REFERENCES fuel_grades(code);
+ALTER TABLE fuel_prices ALTER COLUMN grade_code DROP DEFAULT;
+
+ALTER TABLE refuel_operations ADD COLUMN IF NOT EXISTS grade_code VARCHAR(16) NOT NULL DEFAULT 'AI92' REFERENCES fuel_grades(code);
+ALTER TABLE refuel_operations ALTER COLUMN grade_code DROP DEFAULT;
+
+-- Одна активная цена на марку
+DROP INDEX IF EXISTS idx_fuel_prices_one_active;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_fuel_prices_one_active_per_grade ON fuel_prices(grade_code) WHERE is_active;
+CREATE INDEX IF NOT EXISTS idx_fuel_prices_grade_created_at ON fuel_prices(grade_code, created_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_refuel_grade_date ON refuel_operations(grade_code, created_at DESC);
@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS idx_refuel_device_status;
+
+ALTER TABLE refuel_operations DROP CONSTRAINT IF EXISTS fk_refuel_operations_device;
+ALTER TABLE refuel_operations ALTER COLUMN device_id DROP NOT NULL;
+
+ALTER TABLE counters DROP CONSTRAINT IF EXISTS fk_counters_device;
+
+ALTER TABLE counter_states DROP CONSTRAINT IF EXISTS fk_counter_states_device;
+ALTER TABLE counter_states ALTER COLUMN device_id SET DEFAULT 'default';
+
+DROP TABLE IF EXISTS devices;
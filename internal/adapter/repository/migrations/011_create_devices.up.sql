@@ -0,0 +1,33 @@
+-- Реестр колонок: у каждой свой счётчик и свои операции
+CREATE TABLE IF NOT EXISTS devices(
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- До реестра была одна колонка 'default'; регистрируем её и все уже встречавшиеся ID
+UPDATE refuel_operations SET device_id = 'default' WHERE device_id IS NULL;
+
+INSERT INTO devices (id, name)
+SELECT DISTINCT device_id, device_id FROM (
+    SELECT 'default' AS device_id
+    UNION SELECT device_id FROM counters
+    UNION SELECT device_id FROM counter_states
+    UNION SELECT device_id FROM refuel_operations
+) known
+ON CONFLICT (id) DO NOTHING;
+
+ALTER TABLE counter_states ALTER COLUMN device_id DROP DEFAULT;
+ALTER TABLE counter_states ADD CONSTRAINT fk_counter_states_device
+    FOREIGN KEY (device_id) REFERENCES devices(id);
+
+ALTER TABLE counters ADD CONSTRAINT fk_counters_device
+    FOREIGN KEY (device_id) REFERENCES devices(id);
+
+ALTER TABLE refuel_operations ALTER COLUMN device_id SET NOT NULL;
+ALTER TABLE refuel_operations ADD CONSTRAINT fk_refuel_operations_device
+    FOREIGN KEY (device_id) REFERENCES devices(id);
+
+-- Незавершённые операции ищутся по колонке
+CREATE INDEX IF NOT EXISTS idx_refuel_device_status ON refuel_operations(device_id, status);
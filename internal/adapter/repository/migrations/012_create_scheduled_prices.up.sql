@@ -0,0 +1,19 @@
+-- Запланированные смены цен; фоновый активатор применяет наступившие ровно один раз
+CREATE TABLE IF NOT EXISTS scheduled_prices(
+    id BIGSERIAL PRIMARY KEY,
+    grade_code VARCHAR(16) NOT NULL REFERENCES fuel_grades(code),
+    price_per_liter_kop BIGINT NOT NULL CHECK (price_per_liter_kop > 0),
+    effective_from TIMESTAMP WITH TIME ZONE NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'Pending' CHECK (status IN ('Pending', 'Applied', 'Cancelled')),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    applied_at TIMESTAMP WITH TIME ZONE NULL,
+    cancelled_at TIMESTAMP WITH TIME ZONE NULL,
+
+    CHECK ((status = 'Applied') = (applied_at IS NOT NULL)),
+    CHECK ((status = 'Cancelled') = (cancelled_at IS NOT NULL))
+);
+
+-- Активатор ищет только ожидающие цены по времени
+CREATE INDEX IF NOT EXISTS idx_scheduled_prices_pending
+    ON scheduled_prices(effective_from, id) WHERE status = 'Pending';
+CREATE INDEX IF NOT EXISTS idx_scheduled_prices_grade ON scheduled_prices(grade_code, effective_from);
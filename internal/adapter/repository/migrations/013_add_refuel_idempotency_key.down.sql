@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_refuel_idempotency_key;
+
+ALTER TABLE refuel_operations DROP COLUMN IF EXISTS idempotency_key;
@@ -0,0 +1,5 @@
+-- Ключ идемпотентности от терминала: повтор CreateRefuel с тем же ключом возвращает исходную операцию
+ALTER TABLE refuel_operations ADD COLUMN IF NOT EXISTS idempotency_key VARCHAR(128) NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_refuel_idempotency_key
+    ON refuel_operations(idempotency_key) WHERE idempotency_key IS NOT NULL;
@@ -0,0 +1,12 @@
+-- Частично выполненные операции остаются подтверждёнными
+UPDATE refuel_operations SET status = 'Confirmed' WHERE status = 'PartiallyFulfilled';
+
+ALTER TABLE refuel_operations DROP CONSTRAINT IF EXISTS chk_refuel_status;
+ALTER TABLE refuel_operations ADD CONSTRAINT chk_refuel_status
+    CHECK (status IN ('Created', 'Confirmed', 'Cancelled'));
+
+ALTER TABLE refuel_operations DROP CONSTRAINT IF EXISTS chk_refuel_refund_due;
+ALTER TABLE refuel_operations DROP CONSTRAINT IF EXISTS chk_refuel_dispensed_volume;
+
+ALTER TABLE refuel_operations DROP COLUMN IF EXISTS refund_due_kop;
+ALTER TABLE refuel_operations DROP COLUMN IF EXISTS dispensed_volume;
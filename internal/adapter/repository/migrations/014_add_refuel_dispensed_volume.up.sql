@@ -0,0 +1,12 @@
+-- Подтверждение по фактическому показанию счётчика: сколько отпущено и сколько вернуть клиенту
+ALTER TABLE refuel_operations ADD COLUMN IF NOT EXISTS dispensed_volume BIGINT NULL;
+ALTER TABLE refuel_operations ADD COLUMN IF NOT EXISTS refund_due_kop BIGINT NOT NULL DEFAULT 0;
+
+ALTER TABLE refuel_operations ADD CONSTRAINT chk_refuel_dispensed_volume
+    CHECK (dispensed_volume IS NULL OR dispensed_volume >= 0);
+ALTER TABLE refuel_operations ADD CONSTRAINT chk_refuel_refund_due
+    CHECK (refund_due_kop >= 0 AND refund_due_kop <= amount_paid_kop);
+
+ALTER TABLE refuel_operations DROP CONSTRAINT IF EXISTS chk_refuel_status;
+ALTER TABLE refuel_operations ADD CONSTRAINT chk_refuel_status
+    CHECK (status IN ('Created', 'Confirmed', 'PartiallyFulfilled', 'Cancelled'));
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_refuel_refund_outstanding;
+
+ALTER TABLE refuel_operations DROP CONSTRAINT IF EXISTS chk_refuel_refunded;
+ALTER TABLE refuel_operations DROP COLUMN IF EXISTS refunded_kop;
+
+DROP TABLE IF EXISTS refunds;
@@ -0,0 +1,21 @@
+-- Возвраты денег клиенту по операции (например, за недолитое топливо)
+CREATE TABLE IF NOT EXISTS refunds(
+    id BIGSERIAL PRIMARY KEY,
+    operation_id BIGINT NOT NULL REFERENCES refuel_operations(id),
+    amount_kop BIGINT NOT NULL CHECK (amount_kop > 0),
+    method VARCHAR(20) NOT NULL CHECK (method IN ('Cash', 'Card')),
+    issued_by TEXT NOT NULL,
+    issued_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_refunds_operation ON refunds(operation_id);
+CREATE INDEX IF NOT EXISTS idx_refunds_issued_at ON refunds(issued_at DESC);
+
+-- Сколько уже выдано по операции; не больше суммы к возврату
+ALTER TABLE refuel_operations ADD COLUMN IF NOT EXISTS refunded_kop BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE refuel_operations ADD CONSTRAINT chk_refuel_refunded
+    CHECK (refunded_kop >= 0 AND refunded_kop <= refund_due_kop);
+
+-- Список невыданных возвратов
+CREATE INDEX IF NOT EXISTS idx_refuel_refund_outstanding
+    ON refuel_operations(created_at DESC) WHERE refunded_kop < refund_due_kop;
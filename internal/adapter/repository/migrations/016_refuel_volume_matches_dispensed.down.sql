@@ -0,0 +1,3 @@
+ALTER TABLE refuel_operations DROP CONSTRAINT IF EXISTS chk_refuel_volume_matches_counter;
+ALTER TABLE refuel_operations ADD CONSTRAINT chk_refuel_volume_matches_counter
+    CHECK (calculated_volume = counter_after - counter_before) NOT VALID;
@@ -0,0 +1,4 @@
+-- После подтверждения по счётчику сдвиг счётчика равен отпущенному, а не рассчитанному объёму
+ALTER TABLE refuel_operations DROP CONSTRAINT IF EXISTS chk_refuel_volume_matches_counter;
+ALTER TABLE refuel_operations ADD CONSTRAINT chk_refuel_volume_matches_counter
+    CHECK (COALESCE(dispensed_volume, calculated_volume) = counter_after - counter_before);
@@ -0,0 +1,5 @@
+ALTER TABLE refuel_operations DROP CONSTRAINT IF EXISTS chk_refuel_amount_positive;
+ALTER TABLE refuel_operations ADD CONSTRAINT chk_refuel_amount_positive CHECK (amount_paid_kop > 0) NOT VALID;
+
+ALTER TABLE refuel_operations DROP CONSTRAINT IF EXISTS chk_refuel_mode;
+ALTER TABLE refuel_operations DROP COLUMN IF EXISTS mode;
@@ -0,0 +1,9 @@
+-- Способ заправки: на сумму, на объём или до полного бака
+ALTER TABLE refuel_operations ADD COLUMN IF NOT EXISTS mode VARCHAR(10) NOT NULL DEFAULT 'Amount';
+ALTER TABLE refuel_operations ADD CONSTRAINT chk_refuel_mode
+    CHECK (mode IN ('Amount', 'Volume', 'FillUp'));
+
+-- Сумма FillUp известна только после подтверждения по счётчику
+ALTER TABLE refuel_operations DROP CONSTRAINT IF EXISTS chk_refuel_amount_positive;
+ALTER TABLE refuel_operations ADD CONSTRAINT chk_refuel_amount_positive
+    CHECK (amount_paid_kop > 0 OR (mode = 'FillUp' AND status IN ('Created', 'Cancelled')));
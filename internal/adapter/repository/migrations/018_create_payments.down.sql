@@ -0,0 +1,4 @@
+ALTER TABLE refuel_operations DROP CONSTRAINT IF EXISTS chk_refuel_paid;
+ALTER TABLE refuel_operations DROP COLUMN IF EXISTS paid_kop;
+
+DROP TABLE IF EXISTS payments;
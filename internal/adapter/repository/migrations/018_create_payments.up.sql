@@ -0,0 +1,16 @@
+-- Платежи по операции: способ оплаты, сумма и номер внешней транзакции
+CREATE TABLE IF NOT EXISTS payments(
+    id BIGSERIAL PRIMARY KEY,
+    operation_id BIGINT NOT NULL REFERENCES refuel_operations(id),
+    method VARCHAR(20) NOT NULL CHECK (method IN ('Cash', 'Card', 'SBP', 'FuelCard')),
+    amount_kop BIGINT NOT NULL CHECK (amount_kop > 0),
+    external_ref VARCHAR(128) NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_payments_operation ON payments(operation_id);
+
+-- Сколько уже разнесено по платежам; не больше суммы операции
+ALTER TABLE refuel_operations ADD COLUMN IF NOT EXISTS paid_kop BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE refuel_operations ADD CONSTRAINT chk_refuel_paid
+    CHECK (paid_kop >= 0 AND paid_kop <= amount_paid_kop);
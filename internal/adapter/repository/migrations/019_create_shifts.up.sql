@@ -0,0 +1,23 @@
+-- Смены операторов: кто работал, с какой наличностью в кассе открыл и закрыл смену
+CREATE TABLE IF NOT EXISTS shifts(
+    id BIGSERIAL PRIMARY KEY,
+    operator TEXT NOT NULL,
+    opened_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    closed_at TIMESTAMP WITH TIME ZONE NULL,
+    opening_cash_kop BIGINT NOT NULL CHECK (opening_cash_kop >= 0),
+    closing_cash_kop BIGINT NULL CHECK (closing_cash_kop >= 0),
+    CONSTRAINT chk_shift_closed CHECK ((closed_at IS NULL) = (closing_cash_kop IS NULL))
+);
+
+-- Открытой может быть только одна смена
+CREATE UNIQUE INDEX IF NOT EXISTS uq_shifts_open ON shifts((true)) WHERE closed_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_shifts_opened_at ON shifts(opened_at DESC);
+
+-- Показания счётчиков колонок на открытии и закрытии смены
+CREATE TABLE IF NOT EXISTS shift_counters(
+    shift_id BIGINT NOT NULL REFERENCES shifts(id),
+    device_id TEXT NOT NULL REFERENCES devices(id),
+    start_value BIGINT NOT NULL,
+    end_value BIGINT NULL,
+    PRIMARY KEY (shift_id, device_id)
+);
@@ -0,0 +1,10 @@
+-- Неудавшиеся цены в старой схеме выразить нельзя: считаем их отменёнными
+UPDATE scheduled_prices SET status = 'Cancelled', cancelled_at = failed_at WHERE status = 'Failed';
+
+ALTER TABLE scheduled_prices DROP CONSTRAINT IF EXISTS chk_scheduled_price_failed;
+ALTER TABLE scheduled_prices DROP CONSTRAINT IF EXISTS chk_scheduled_price_status;
+ALTER TABLE scheduled_prices ADD CONSTRAINT scheduled_prices_status_check
+    CHECK (status IN ('Pending', 'Applied', 'Cancelled'));
+
+ALTER TABLE scheduled_prices DROP COLUMN IF EXISTS failure_reason;
+ALTER TABLE scheduled_prices DROP COLUMN IF EXISTS failed_at;
@@ -0,0 +1,9 @@
+-- Цена, которую не удалось применить, помечается Failed, и активатор идёт дальше
+ALTER TABLE scheduled_prices ADD COLUMN IF NOT EXISTS failed_at TIMESTAMP WITH TIME ZONE NULL;
+ALTER TABLE scheduled_prices ADD COLUMN IF NOT EXISTS failure_reason TEXT NULL;
+
+ALTER TABLE scheduled_prices DROP CONSTRAINT IF EXISTS scheduled_prices_status_check;
+ALTER TABLE scheduled_prices ADD CONSTRAINT chk_scheduled_price_status
+    CHECK (status IN ('Pending', 'Applied', 'Cancelled', 'Failed'));
+ALTER TABLE scheduled_prices ADD CONSTRAINT chk_scheduled_price_failed
+    CHECK ((status = 'Failed') = (failed_at IS NOT NULL));
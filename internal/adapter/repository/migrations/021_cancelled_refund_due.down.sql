@@ -0,0 +1,2 @@
+-- Суммы к возврату по отменённым операциям остаются как есть: прежние значения не восстановить
+ALTER TABLE refuel_operations DROP CONSTRAINT IF EXISTS chk_refuel_cancelled_refund_due;
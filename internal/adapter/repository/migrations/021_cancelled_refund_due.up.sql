@@ -0,0 +1,5 @@
+-- Отменённая операция должна вернуть клиенту всю оплаченную сумму
+UPDATE refuel_operations SET refund_due_kop = amount_paid_kop WHERE status = 'Cancelled';
+
+ALTER TABLE refuel_operations ADD CONSTRAINT chk_refuel_cancelled_refund_due
+    CHECK (status <> 'Cancelled' OR refund_due_kop = amount_paid_kop);
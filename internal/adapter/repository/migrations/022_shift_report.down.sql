@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_payments_created_at;
+
+ALTER TABLE shifts DROP CONSTRAINT IF EXISTS chk_shift_report;
+ALTER TABLE shifts DROP COLUMN IF EXISTS report;
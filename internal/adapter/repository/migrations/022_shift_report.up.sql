@@ -0,0 +1,6 @@
+-- Z-отчёт фиксируется при закрытии смены: поздние изменения операций его не меняют
+ALTER TABLE shifts ADD COLUMN IF NOT EXISTS report JSONB NULL;
+ALTER TABLE shifts ADD CONSTRAINT chk_shift_report CHECK (report IS NULL OR closed_at IS NOT NULL);
+
+-- Движение денег за смену: платежи по времени приёма (возвраты уже индексированы по issued_at)
+CREATE INDEX IF NOT EXISTS idx_payments_created_at ON payments(created_at);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_refuel_operations_cancelled_at;
+DROP INDEX IF EXISTS idx_refuel_operations_confirmed_at;
+
+ALTER TABLE refuel_operations DROP CONSTRAINT IF EXISTS chk_refuel_confirmed_at;
+ALTER TABLE refuel_operations DROP COLUMN IF EXISTS confirmed_at;
@@ -0,0 +1,13 @@
+-- Время подтверждения: счётчик сдвигается именно тогда, по нему сверяется смена.
+-- Для уже подтверждённых операций точное время неизвестно, берём время создания
+ALTER TABLE refuel_operations ADD COLUMN IF NOT EXISTS confirmed_at TIMESTAMP WITH TIME ZONE NULL;
+UPDATE refuel_operations SET confirmed_at = created_at
+    WHERE confirmed_at IS NULL AND status IN ('Confirmed', 'PartiallyFulfilled');
+
+ALTER TABLE refuel_operations ADD CONSTRAINT chk_refuel_confirmed_at
+    CHECK (status = 'Cancelled' OR (confirmed_at IS NOT NULL) = (status IN ('Confirmed', 'PartiallyFulfilled')));
+
+CREATE INDEX IF NOT EXISTS idx_refuel_operations_confirmed_at
+    ON refuel_operations(confirmed_at) WHERE confirmed_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_refuel_operations_cancelled_at
+    ON refuel_operations(cancelled_at) WHERE cancelled_at IS NOT NULL;
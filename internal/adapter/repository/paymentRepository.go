@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"fuelStation/internal/domain/entity"
+	"fuelStation/internal/domain/service"
+	"time"
+)
+
+type PaymentRepository struct {
+	db *sql.DB
+}
+
+func NewPaymentRepository(db *sql.DB) *PaymentRepository {
+	return &PaymentRepository{
+		db: db,
+	}
+}
+
+const paymentColumns = "id, operation_id, method, amount_kop, external_ref, created_at"
+
+// Сохранить платёж и увеличить оплаченную сумму операции (в одной транзакции).
+// Блокировка строки операции не даёт двум платежам вместе превысить сумму операции
+func (r *PaymentRepository) Create(ctx context.Context, payment *entity.Payment) error {
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		res, err := tx.ExecContext(ctx,
+			`UPDATE refuel_operations SET paid_kop = paid_kop + $1
+			WHERE id = $2 AND status <> $3 AND paid_kop + $1 <= amount_paid_kop`,
+			int64(payment.Amount), payment.OperationID, service.RefuelStatusCancelled,
+		)
+		if err != nil {
+			return fmt.Errorf("ошибка учёта платежа по операции %d: %w", payment.OperationID, err)
+		}
+
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("ошибка учёта платежа по операции %d: %w", payment.OperationID, err)
+		}
+		if affected == 0 {
+			return service.ErrPaymentExceedsAmount
+		}
+
+		if err := tx.QueryRowContext(ctx,
+			`INSERT INTO payments (operation_id, method, amount_kop, external_ref, created_at)
+			VALUES ($1, $2, $3, $4, $5)
+			RETURNING id`,
+			payment.OperationID, payment.Method, int64(payment.Amount), payment.ExternalRef, payment.CreatedAt,
+		).Scan(&payment.ID); err != nil {
+			return fmt.Errorf("ошибка сохранения платежа: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// Платежи по операции
+func (r *PaymentRepository) ListByOperation(ctx context.Context, operationID int64) ([]entity.Payment, error) {
+	return r.list(ctx, "operation_id = $1", operationID)
+}
+
+// Платежи по нескольким операциям (для статистики)
+func (r *PaymentRepository) ListByOperations(ctx context.Context, operationIDs []int64) ([]entity.Payment, error) {
+	if len(operationIDs) == 0 {
+		return nil, nil
+	}
+	return r.list(ctx, "operation_id = ANY($1)", operationIDs)
+}
+
+// Платежи, принятые в промежутке [from, to)
+func (r *PaymentRepository) ListByPeriod(ctx context.Context, from, to time.Time) ([]entity.Payment, error) {
+	return r.list(ctx, "created_at >= $1 AND created_at < $2", from, to)
+}
+
+func (r *PaymentRepository) list(ctx context.Context, cond string, args ...any) ([]entity.Payment, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx,
+		"SELECT "+paymentColumns+" FROM payments WHERE "+cond+" ORDER BY created_at, id", args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения платежей: %w", err)
+	}
+	defer rows.Close()
+
+	var payments []entity.Payment
+	for rows.Next() {
+		var (
+			p   entity.Payment
+			ref sql.NullString
+		)
+		if err := rows.Scan(&p.ID, &p.OperationID, &p.Method, &p.Amount, &ref, &p.CreatedAt); err != nil {
+			return nil, fmt.Errorf("ошибка чтения платежа: %w", err)
+		}
+		if ref.Valid {
+			p.ExternalRef = &ref.String
+		}
+		payments = append(payments, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка получения платежей: %w", err)
+	}
+
+	return payments, nil
+}
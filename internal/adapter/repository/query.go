@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Собирает условия WHERE и аргументы для динамических запросов
+type queryBuilder struct {
+	conds []string
+	args  []any
+}
+
+// Добавляет условие, cond должен содержать один %d под номер плейсхолдера
+func (b *queryBuilder) add(cond string, arg any) {
+	b.args = append(b.args, arg)
+	b.conds = append(b.conds, fmt.Sprintf(cond, len(b.args)))
+}
+
+// Добавляет условие без аргументов
+func (b *queryBuilder) addCond(cond string) {
+	b.conds = append(b.conds, cond)
+}
+
+// Возвращает часть запроса WHERE (или пустую строку)
+func (b *queryBuilder) where() string {
+	if len(b.conds) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(b.conds, " AND ")
+}
+
+// Возвращает LIMIT/OFFSET если они заданы
+func (b *queryBuilder) paginate(limit, offset *int) string {
+	var sb strings.Builder
+	if limit != nil {
+		b.args = append(b.args, *limit)
+		fmt.Fprintf(&sb, " LIMIT $%d", len(b.args))
+	}
+	if offset != nil {
+		b.args = append(b.args, *offset)
+		fmt.Fprintf(&sb, " OFFSET $%d", len(b.args))
+	}
+	return sb.String()
+}
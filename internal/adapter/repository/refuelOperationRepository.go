@@ -0,0 +1,279 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"fuelStation/internal/domain/entity"
+	"fuelStation/internal/domain/interfaces"
+	"fuelStation/internal/domain/service"
+	"strconv"
+	"time"
+)
+
+type RefuelOperationRepository struct {
+	db *sql.DB
+}
+
+func NewRefuelOperationRepository(db *sql.DB) *RefuelOperationRepository {
+	return &RefuelOperationRepository{
+		db: db,
+	}
+}
+
+const refuelOperationColumns = "id, device_id, grade_code, mode, amount_paid_kop, paid_kop, calculated_volume, dispensed_volume, refund_due_kop, refunded_kop, price_per_liter_kop, counter_before, counter_after, status, created_at, confirmed_at, cancelled_at, cancelled_reason, idempotency_key"
+
+// Создать операцию (ID проставляется базой).
+// Если ключ идемпотентности уже занят, строка не вставляется и возвращается ErrDuplicateIdempotencyKey
+func (r *RefuelOperationRepository) Create(ctx context.Context, operation *entity.RefuelOperation) error {
+	err := conn(ctx, r.db).QueryRowContext(ctx,
+		`INSERT INTO refuel_operations
+			(device_id, grade_code, mode, amount_paid_kop, calculated_volume, price_per_liter_kop, counter_before, counter_after, status, created_at, idempotency_key)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		ON CONFLICT (idempotency_key) WHERE idempotency_key IS NOT NULL DO NOTHING
+		RETURNING id`,
+		operation.DeviceID, operation.GradeCode, operation.Mode, int64(operation.AmountPaid), int64(operation.CalculatedLiters), int64(operation.PricePerLiter),
+		operation.CounterBefore, operation.CounterAfter, operation.Status, operation.CreatedAt, operation.IdempotencyKey,
+	).Scan(&operation.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return service.ErrDuplicateIdempotencyKey
+	}
+	if err != nil {
+		return fmt.Errorf("ошибка создания операции: %w", err)
+	}
+
+	return nil
+}
+
+// Получить по ID
+func (r *RefuelOperationRepository) GetByID(ctx context.Context, id string) (entity.RefuelOperation, error) {
+	operationID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return entity.RefuelOperation{}, service.ErrNotFoundOper
+	}
+
+	row := conn(ctx, r.db).QueryRowContext(ctx,
+		"SELECT "+refuelOperationColumns+" FROM refuel_operations WHERE id = $1", operationID,
+	)
+
+	operation, err := scanRefuelOperation(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.RefuelOperation{}, service.ErrNotFoundOper
+	}
+	if err != nil {
+		return entity.RefuelOperation{}, fmt.Errorf("ошибка получения операции %s: %w", id, err)
+	}
+
+	return operation, nil
+}
+
+// Получить по ключу идемпотентности
+func (r *RefuelOperationRepository) GetByIdempotencyKey(ctx context.Context, key string) (entity.RefuelOperation, error) {
+	row := conn(ctx, r.db).QueryRowContext(ctx,
+		"SELECT "+refuelOperationColumns+" FROM refuel_operations WHERE idempotency_key = $1", key,
+	)
+
+	operation, err := scanRefuelOperation(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.RefuelOperation{}, service.ErrNotFoundOper
+	}
+	if err != nil {
+		return entity.RefuelOperation{}, fmt.Errorf("ошибка получения операции по ключу идемпотентности: %w", err)
+	}
+
+	return operation, nil
+}
+
+// Универсальный поиск операций
+func (r *RefuelOperationRepository) Find(ctx context.Context, filter interfaces.RefuelFilter) ([]entity.RefuelOperation, error) {
+	var b queryBuilder
+
+	if filter.DeviceID != nil {
+		b.add("device_id = $%d", *filter.DeviceID)
+	}
+	if filter.Grade != nil {
+		b.add("grade_code = $%d", *filter.Grade)
+	}
+	if filter.DateFrom != nil {
+		b.add("created_at >= $%d", *filter.DateFrom)
+	}
+	if filter.DateTo != nil {
+		b.add("created_at <= $%d", *filter.DateTo)
+	}
+	if filter.ConfirmedFrom != nil {
+		b.add("confirmed_at >= $%d", *filter.ConfirmedFrom)
+	}
+	if filter.ConfirmedTo != nil {
+		b.add("confirmed_at <= $%d", *filter.ConfirmedTo)
+	}
+	if filter.CancelledFrom != nil {
+		b.add("cancelled_at >= $%d", *filter.CancelledFrom)
+	}
+	if filter.CancelledTo != nil {
+		b.add("cancelled_at <= $%d", *filter.CancelledTo)
+	}
+	if filter.Status != nil && *filter.Status != "" {
+		b.add("status = $%d", *filter.Status)
+	}
+	if len(filter.Statuses) > 0 {
+		b.add("status = ANY($%d)", filter.Statuses)
+	}
+	if filter.RefundOutstanding {
+		b.addCond("refunded_kop < refund_due_kop")
+	}
+
+	query := "SELECT " + refuelOperationColumns + " FROM refuel_operations" + b.where() +
+		" ORDER BY created_at DESC, id DESC" + b.paginate(filter.Limit, filter.Offset)
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, b.args...)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка поиска операций: %w", err)
+	}
+	defer rows.Close()
+
+	var operations []entity.RefuelOperation
+	for rows.Next() {
+		operation, err := scanRefuelOperation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка чтения операции: %w", err)
+		}
+		operations = append(operations, operation)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка поиска операций: %w", err)
+	}
+
+	return operations, nil
+}
+
+// Обновить статус операции, если она всё ещё в одном из статусов expected.
+// Если статус успели сменить, возвращается ErrInvalidOperationStatus. При отмене сохраняются причина
+// и время отмены, а к возврату становится вся оплаченная сумма
+func (r *RefuelOperationRepository) UpdateStatus(ctx context.Context, id string, status string, expected []string, reason *string) error {
+	operationID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return service.ErrNotFoundOper
+	}
+
+	var res sql.Result
+	if status == service.RefuelStatusCancelled {
+		res, err = conn(ctx, r.db).ExecContext(ctx,
+			`UPDATE refuel_operations SET status = $1, cancelled_at = now(), cancelled_reason = $2, refund_due_kop = amount_paid_kop
+			WHERE id = $3 AND status = ANY($4)`,
+			status, reason, operationID, expected,
+		)
+	} else {
+		res, err = conn(ctx, r.db).ExecContext(ctx,
+			`UPDATE refuel_operations SET status = $1,
+				confirmed_at = CASE WHEN $1 IN ('Confirmed', 'PartiallyFulfilled') THEN now() ELSE confirmed_at END
+			WHERE id = $2 AND status = ANY($3)`,
+			status, operationID, expected,
+		)
+	}
+	if err != nil {
+		return fmt.Errorf("ошибка обновления статуса операции %s: %w", id, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("ошибка обновления статуса операции %s: %w", id, err)
+	}
+	if affected == 0 {
+		return service.ErrInvalidOperationStatus
+	}
+
+	return nil
+}
+
+// Отменить операцию, если она всё ещё в Created и создана не позже before.
+// Иначе (подтверждена, отменена или пересоздана позже) возвращается ErrInvalidOperationStatus
+func (r *RefuelOperationRepository) CancelExpired(ctx context.Context, id int64, before time.Time, reason string) (entity.RefuelOperation, error) {
+	row := conn(ctx, r.db).QueryRowContext(ctx,
+		`UPDATE refuel_operations SET status = $1, cancelled_at = now(), cancelled_reason = $2, refund_due_kop = amount_paid_kop
+		WHERE id = $3 AND status = $4 AND created_at <= $5
+		RETURNING `+refuelOperationColumns,
+		service.RefuelStatusCancelled, reason, id, service.RefuelStatusCreated, before,
+	)
+
+	operation, err := scanRefuelOperation(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.RefuelOperation{}, service.ErrInvalidOperationStatus
+	}
+	if err != nil {
+		return entity.RefuelOperation{}, fmt.Errorf("ошибка отмены зависшей операции %d: %w", id, err)
+	}
+
+	return operation, nil
+}
+
+// Сохранить итог заправки по счётчику (только для ещё не подтверждённой операции).
+// Сумма и рассчитанный объём тоже пишутся: у FillUp они определяются только здесь
+func (r *RefuelOperationRepository) UpdateDispensed(ctx context.Context, operation entity.RefuelOperation) error {
+	var dispensed sql.NullInt64
+	if operation.DispensedLiters != nil {
+		dispensed = sql.NullInt64{Int64: int64(*operation.DispensedLiters), Valid: true}
+	}
+
+	res, err := conn(ctx, r.db).ExecContext(ctx,
+		`UPDATE refuel_operations
+		SET status = $1, counter_after = $2, dispensed_volume = $3, refund_due_kop = $4,
+			amount_paid_kop = $5, calculated_volume = $6, confirmed_at = now()
+		WHERE id = $7 AND status = $8`,
+		operation.Status, operation.CounterAfter, dispensed, int64(operation.RefundDue),
+		int64(operation.AmountPaid), int64(operation.CalculatedLiters),
+		operation.ID, service.RefuelStatusCreated,
+	)
+	if err != nil {
+		return fmt.Errorf("ошибка сохранения итога операции %d: %w", operation.ID, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("ошибка сохранения итога операции %d: %w", operation.ID, err)
+	}
+	if affected == 0 {
+		return service.ErrInvalidOperationStatus
+	}
+
+	return nil
+}
+
+func scanRefuelOperation(row rowScanner) (entity.RefuelOperation, error) {
+	var (
+		o           entity.RefuelOperation
+		dispensed   sql.NullInt64
+		confirmedAt sql.NullTime
+		cancelledAt sql.NullTime
+		reason      sql.NullString
+		key         sql.NullString
+	)
+
+	err := row.Scan(
+		&o.ID, &o.DeviceID, &o.GradeCode, &o.Mode, &o.AmountPaid, &o.Paid, &o.CalculatedLiters, &dispensed, &o.RefundDue, &o.Refunded, &o.PricePerLiter,
+		&o.CounterBefore, &o.CounterAfter, &o.Status, &o.CreatedAt,
+		&confirmedAt, &cancelledAt, &reason, &key,
+	)
+	if err != nil {
+		return entity.RefuelOperation{}, err
+	}
+
+	if dispensed.Valid {
+		v := entity.Volume(dispensed.Int64)
+		o.DispensedLiters = &v
+	}
+	if confirmedAt.Valid {
+		o.ConfirmedAt = &confirmedAt.Time
+	}
+	if cancelledAt.Valid {
+		o.CancelledAt = &cancelledAt.Time
+	}
+	if reason.Valid {
+		o.CancelReason = &reason.String
+	}
+	if key.Valid {
+		o.IdempotencyKey = &key.String
+	}
+
+	return o, nil
+}
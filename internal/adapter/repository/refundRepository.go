@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"fuelStation/internal/domain/entity"
+	"fuelStation/internal/domain/service"
+	"time"
+)
+
+type RefundRepository struct {
+	db *sql.DB
+}
+
+func NewRefundRepository(db *sql.DB) *RefundRepository {
+	return &RefundRepository{
+		db: db,
+	}
+}
+
+const refundColumns = "id, operation_id, amount_kop, method, issued_by, issued_at"
+
+// Сохранить возврат и увеличить возвращённую сумму операции (в одной транзакции).
+// Блокировка строки операции не даёт двум возвратам вместе превысить сумму к возврату,
+// а проверка статуса — выдать возврат по операции, по которой он не положен
+func (r *RefundRepository) Create(ctx context.Context, refund *entity.Refund) error {
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		res, err := tx.ExecContext(ctx,
+			`UPDATE refuel_operations SET refunded_kop = refunded_kop + $1
+			WHERE id = $2 AND refunded_kop + $1 <= refund_due_kop AND status = ANY($3)`,
+			int64(refund.Amount), refund.OperationID, service.RefundableStatuses(),
+		)
+		if err != nil {
+			return fmt.Errorf("ошибка учёта возврата по операции %d: %w", refund.OperationID, err)
+		}
+
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("ошибка учёта возврата по операции %d: %w", refund.OperationID, err)
+		}
+		if affected == 0 {
+			return service.ErrRefundExceedsDue
+		}
+
+		if err := tx.QueryRowContext(ctx,
+			`INSERT INTO refunds (operation_id, amount_kop, method, issued_by, issued_at)
+			VALUES ($1, $2, $3, $4, $5)
+			RETURNING id`,
+			refund.OperationID, int64(refund.Amount), refund.Method, refund.IssuedBy, refund.IssuedAt,
+		).Scan(&refund.ID); err != nil {
+			return fmt.Errorf("ошибка сохранения возврата: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// Возвраты по операции
+func (r *RefundRepository) ListByOperation(ctx context.Context, operationID int64) ([]entity.Refund, error) {
+	return r.list(ctx, "operation_id = $1", operationID)
+}
+
+// Возвраты по нескольким операциям (для статистики)
+func (r *RefundRepository) ListByOperations(ctx context.Context, operationIDs []int64) ([]entity.Refund, error) {
+	if len(operationIDs) == 0 {
+		return nil, nil
+	}
+	return r.list(ctx, "operation_id = ANY($1)", operationIDs)
+}
+
+// Возвраты, выданные в промежутке [from, to)
+func (r *RefundRepository) ListByPeriod(ctx context.Context, from, to time.Time) ([]entity.Refund, error) {
+	return r.list(ctx, "issued_at >= $1 AND issued_at < $2", from, to)
+}
+
+func (r *RefundRepository) list(ctx context.Context, cond string, args ...any) ([]entity.Refund, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx,
+		"SELECT "+refundColumns+" FROM refunds WHERE "+cond+" ORDER BY issued_at, id", args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения возвратов: %w", err)
+	}
+	defer rows.Close()
+
+	var refunds []entity.Refund
+	for rows.Next() {
+		var rf entity.Refund
+		if err := rows.Scan(&rf.ID, &rf.OperationID, &rf.Amount, &rf.Method, &rf.IssuedBy, &rf.IssuedAt); err != nil {
+			return nil, fmt.Errorf("ошибка чтения возврата: %w", err)
+		}
+		refunds = append(refunds, rf)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка получения возвратов: %w", err)
+	}
+
+	return refunds, nil
+}
@@ -0,0 +1,222 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"fuelStation/internal/domain/entity"
+	"fuelStation/internal/domain/interfaces"
+	"fuelStation/internal/domain/service"
+	"strconv"
+	"time"
+)
+
+type ScheduledPriceRepository struct {
+	db *sql.DB
+}
+
+func NewScheduledPriceRepository(db *sql.DB) *ScheduledPriceRepository {
+	return &ScheduledPriceRepository{
+		db: db,
+	}
+}
+
+const scheduledPriceColumns = "id, grade_code, price_per_liter_kop, effective_from, status, created_at, applied_at, cancelled_at, failed_at, failure_reason"
+
+// Запланировать цену (ID проставляется базой)
+func (r *ScheduledPriceRepository) Create(ctx context.Context, price *entity.ScheduledPrice) error {
+	err := conn(ctx, r.db).QueryRowContext(ctx,
+		`INSERT INTO scheduled_prices (grade_code, price_per_liter_kop, effective_from, status, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id`,
+		price.GradeCode, int64(price.PricePerLiter), price.EffectiveFrom, price.Status, price.CreatedAt,
+	).Scan(&price.ID)
+	if err != nil {
+		return fmt.Errorf("ошибка планирования цены: %w", err)
+	}
+
+	return nil
+}
+
+// Получить по ID
+func (r *ScheduledPriceRepository) GetByID(ctx context.Context, id string) (entity.ScheduledPrice, error) {
+	priceID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return entity.ScheduledPrice{}, service.ErrNotFoundScheduledPrice
+	}
+
+	row := conn(ctx, r.db).QueryRowContext(ctx,
+		"SELECT "+scheduledPriceColumns+" FROM scheduled_prices WHERE id = $1", priceID,
+	)
+
+	price, err := scanScheduledPrice(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.ScheduledPrice{}, service.ErrNotFoundScheduledPrice
+	}
+	if err != nil {
+		return entity.ScheduledPrice{}, fmt.Errorf("ошибка получения запланированной цены %s: %w", id, err)
+	}
+
+	return price, nil
+}
+
+// Поиск запланированных цен
+func (r *ScheduledPriceRepository) Find(ctx context.Context, filter interfaces.ScheduledPriceFilter) ([]entity.ScheduledPrice, error) {
+	var b queryBuilder
+
+	if filter.Grade != nil {
+		b.add("grade_code = $%d", *filter.Grade)
+	}
+	if filter.Status != nil {
+		b.add("status = $%d", *filter.Status)
+	}
+
+	query := "SELECT " + scheduledPriceColumns + " FROM scheduled_prices" + b.where() +
+		" ORDER BY effective_from, id" + b.paginate(filter.Limit, filter.Offset)
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, b.args...)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка поиска запланированных цен: %w", err)
+	}
+	defer rows.Close()
+
+	var prices []entity.ScheduledPrice
+	for rows.Next() {
+		price, err := scanScheduledPrice(rows)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка чтения запланированной цены: %w", err)
+		}
+		prices = append(prices, price)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка поиска запланированных цен: %w", err)
+	}
+
+	return prices, nil
+}
+
+// Отменить ещё не применённую цену
+func (r *ScheduledPriceRepository) Cancel(ctx context.Context, id string) error {
+	priceID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return service.ErrNotFoundScheduledPrice
+	}
+
+	res, err := conn(ctx, r.db).ExecContext(ctx,
+		"UPDATE scheduled_prices SET status = $1, cancelled_at = now() WHERE id = $2 AND status = $3",
+		service.ScheduledPriceStatusCancelled, priceID, service.ScheduledPriceStatusPending,
+	)
+	if err != nil {
+		return fmt.Errorf("ошибка отмены запланированной цены %s: %w", id, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("ошибка отмены запланированной цены %s: %w", id, err)
+	}
+	if affected == 0 {
+		// Либо такой цены нет, либо она уже не ожидает применения
+		if _, err := r.GetByID(ctx, id); err != nil {
+			return err
+		}
+		return service.ErrScheduledPriceNotPending
+	}
+
+	return nil
+}
+
+// Самая ранняя наступившая цена, заблокированная до конца транзакции.
+// SKIP LOCKED: цену, которую уже применяет другой экземпляр, пропускаем
+func (r *ScheduledPriceRepository) LockNextDue(ctx context.Context, now time.Time) (entity.ScheduledPrice, error) {
+	row := conn(ctx, r.db).QueryRowContext(ctx,
+		"SELECT "+scheduledPriceColumns+` FROM scheduled_prices
+		WHERE status = $1 AND effective_from <= $2
+		ORDER BY effective_from, id
+		LIMIT 1
+		FOR UPDATE SKIP LOCKED`,
+		service.ScheduledPriceStatusPending, now,
+	)
+
+	price, err := scanScheduledPrice(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.ScheduledPrice{}, service.ErrNotFoundScheduledPrice
+	}
+	if err != nil {
+		return entity.ScheduledPrice{}, fmt.Errorf("ошибка получения наступившей цены: %w", err)
+	}
+
+	return price, nil
+}
+
+// Отметить цену применённой
+func (r *ScheduledPriceRepository) MarkApplied(ctx context.Context, id int64, appliedAt time.Time) error {
+	res, err := conn(ctx, r.db).ExecContext(ctx,
+		"UPDATE scheduled_prices SET status = $1, applied_at = $2 WHERE id = $3 AND status = $4",
+		service.ScheduledPriceStatusApplied, appliedAt, id, service.ScheduledPriceStatusPending,
+	)
+	if err != nil {
+		return fmt.Errorf("ошибка отметки запланированной цены %d: %w", id, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("ошибка отметки запланированной цены %d: %w", id, err)
+	}
+	if affected == 0 {
+		return service.ErrScheduledPriceNotPending
+	}
+
+	return nil
+}
+
+// Отметить, что цену не удалось применить (только ещё ожидающую)
+func (r *ScheduledPriceRepository) MarkFailed(ctx context.Context, id int64, failedAt time.Time, reason string) error {
+	res, err := conn(ctx, r.db).ExecContext(ctx,
+		"UPDATE scheduled_prices SET status = $1, failed_at = $2, failure_reason = $3 WHERE id = $4 AND status = $5",
+		service.ScheduledPriceStatusFailed, failedAt, reason, id, service.ScheduledPriceStatusPending,
+	)
+	if err != nil {
+		return fmt.Errorf("ошибка отметки запланированной цены %d: %w", id, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("ошибка отметки запланированной цены %d: %w", id, err)
+	}
+	if affected == 0 {
+		return service.ErrScheduledPriceNotPending
+	}
+
+	return nil
+}
+
+func scanScheduledPrice(row rowScanner) (entity.ScheduledPrice, error) {
+	var (
+		p           entity.ScheduledPrice
+		appliedAt   sql.NullTime
+		cancelledAt sql.NullTime
+		failedAt    sql.NullTime
+		reason      sql.NullString
+	)
+
+	err := row.Scan(&p.ID, &p.GradeCode, &p.PricePerLiter, &p.EffectiveFrom, &p.Status, &p.CreatedAt, &appliedAt, &cancelledAt, &failedAt, &reason)
+	if err != nil {
+		return entity.ScheduledPrice{}, err
+	}
+
+	if appliedAt.Valid {
+		p.AppliedAt = &appliedAt.Time
+	}
+	if cancelledAt.Valid {
+		p.CancelledAt = &cancelledAt.Time
+	}
+	if failedAt.Valid {
+		p.FailedAt = &failedAt.Time
+	}
+	if reason.Valid {
+		p.FailureReason = &reason.String
+	}
+
+	return p, nil
+}
@@ -0,0 +1,238 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"fuelStation/internal/domain/entity"
+	"fuelStation/internal/domain/interfaces"
+	"fuelStation/internal/domain/service"
+	"strconv"
+)
+
+type ShiftRepository struct {
+	db *sql.DB
+}
+
+func NewShiftRepository(db *sql.DB) *ShiftRepository {
+	return &ShiftRepository{
+		db: db,
+	}
+}
+
+const shiftColumns = "id, operator, opened_at, closed_at, opening_cash_kop, closing_cash_kop"
+
+// Открыть смену и сохранить показания счётчиков (в одной транзакции).
+// Уникальный индекс по открытой смене не даёт открыть вторую, в том числе параллельно
+func (r *ShiftRepository) Create(ctx context.Context, shift *entity.Shift) error {
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		err := tx.QueryRowContext(ctx,
+			`INSERT INTO shifts (operator, opened_at, opening_cash_kop)
+			VALUES ($1, $2, $3)
+			ON CONFLICT DO NOTHING
+			RETURNING id`,
+			shift.Operator, shift.OpenedAt, int64(shift.OpeningCash),
+		).Scan(&shift.ID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return service.ErrShiftAlreadyOpen
+		}
+		if err != nil {
+			return fmt.Errorf("ошибка открытия смены: %w", err)
+		}
+
+		for _, c := range shift.Counters {
+			if _, err := tx.ExecContext(ctx,
+				"INSERT INTO shift_counters (shift_id, device_id, start_value) VALUES ($1, $2, $3)",
+				shift.ID, c.DeviceID, c.StartValue,
+			); err != nil {
+				return fmt.Errorf("ошибка сохранения счётчика %s на открытии смены: %w", c.DeviceID, err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// Открытая смена
+func (r *ShiftRepository) GetOpen(ctx context.Context) (entity.Shift, error) {
+	row := conn(ctx, r.db).QueryRowContext(ctx,
+		"SELECT "+shiftColumns+" FROM shifts WHERE closed_at IS NULL",
+	)
+
+	shift, err := scanShift(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.Shift{}, service.ErrNoOpenShift
+	}
+	if err != nil {
+		return entity.Shift{}, fmt.Errorf("ошибка получения открытой смены: %w", err)
+	}
+
+	return r.withCounters(ctx, shift)
+}
+
+// Получить по ID
+func (r *ShiftRepository) GetByID(ctx context.Context, id string) (entity.Shift, error) {
+	shiftID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return entity.Shift{}, service.ErrNotFoundShift
+	}
+
+	row := conn(ctx, r.db).QueryRowContext(ctx,
+		"SELECT "+shiftColumns+" FROM shifts WHERE id = $1", shiftID,
+	)
+
+	shift, err := scanShift(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.Shift{}, service.ErrNotFoundShift
+	}
+	if err != nil {
+		return entity.Shift{}, fmt.Errorf("ошибка получения смены %s: %w", id, err)
+	}
+
+	return r.withCounters(ctx, shift)
+}
+
+// Закрыть смену и сохранить показания счётчиков на закрытии и Z-отчёт (в одной транзакции)
+func (r *ShiftRepository) Close(ctx context.Context, shift entity.Shift, report []byte) error {
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		var closingCash *int64
+		if shift.ClosingCash != nil {
+			v := int64(*shift.ClosingCash)
+			closingCash = &v
+		}
+
+		res, err := tx.ExecContext(ctx,
+			"UPDATE shifts SET closed_at = $1, closing_cash_kop = $2, report = $3::jsonb WHERE id = $4 AND closed_at IS NULL",
+			shift.ClosedAt, closingCash, string(report), shift.ID,
+		)
+		if err != nil {
+			return fmt.Errorf("ошибка закрытия смены %d: %w", shift.ID, err)
+		}
+
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("ошибка закрытия смены %d: %w", shift.ID, err)
+		}
+		if affected == 0 {
+			return service.ErrNoOpenShift
+		}
+
+		for _, c := range shift.Counters {
+			if _, err := tx.ExecContext(ctx,
+				"UPDATE shift_counters SET end_value = $1 WHERE shift_id = $2 AND device_id = $3",
+				c.EndValue, shift.ID, c.DeviceID,
+			); err != nil {
+				return fmt.Errorf("ошибка сохранения счётчика %s на закрытии смены: %w", c.DeviceID, err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// Z-отчёт, сохранённый при закрытии смены (nil, если смена открыта или закрыта без него)
+func (r *ShiftRepository) GetReport(ctx context.Context, id int64) ([]byte, error) {
+	var report []byte
+	err := conn(ctx, r.db).QueryRowContext(ctx,
+		"SELECT report FROM shifts WHERE id = $1", id,
+	).Scan(&report)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, service.ErrNotFoundShift
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения отчёта смены %d: %w", id, err)
+	}
+
+	return report, nil
+}
+
+// Поиск смен
+func (r *ShiftRepository) Find(ctx context.Context, filter interfaces.ShiftFilter) ([]entity.Shift, error) {
+	var b queryBuilder
+
+	if filter.Operator != nil {
+		b.add("operator = $%d", *filter.Operator)
+	}
+	if filter.DateFrom != nil {
+		b.add("opened_at >= $%d", *filter.DateFrom)
+	}
+	if filter.DateTo != nil {
+		b.add("opened_at <= $%d", *filter.DateTo)
+	}
+
+	query := "SELECT " + shiftColumns + " FROM shifts" + b.where() +
+		" ORDER BY opened_at DESC, id DESC" + b.paginate(filter.Limit, filter.Offset)
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, b.args...)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка поиска смен: %w", err)
+	}
+	defer rows.Close()
+
+	var shifts []entity.Shift
+	for rows.Next() {
+		shift, err := scanShift(rows)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка чтения смены: %w", err)
+		}
+		shifts = append(shifts, shift)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка поиска смен: %w", err)
+	}
+
+	return shifts, nil
+}
+
+// Дополняет смену показаниями счётчиков
+func (r *ShiftRepository) withCounters(ctx context.Context, shift entity.Shift) (entity.Shift, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx,
+		"SELECT device_id, start_value, end_value FROM shift_counters WHERE shift_id = $1 ORDER BY device_id", shift.ID,
+	)
+	if err != nil {
+		return entity.Shift{}, fmt.Errorf("ошибка получения счётчиков смены %d: %w", shift.ID, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			c   entity.ShiftCounter
+			end sql.NullInt64
+		)
+		if err := rows.Scan(&c.DeviceID, &c.StartValue, &end); err != nil {
+			return entity.Shift{}, fmt.Errorf("ошибка чтения счётчика смены: %w", err)
+		}
+		if end.Valid {
+			c.EndValue = &end.Int64
+		}
+		shift.Counters = append(shift.Counters, c)
+	}
+	if err := rows.Err(); err != nil {
+		return entity.Shift{}, fmt.Errorf("ошибка получения счётчиков смены %d: %w", shift.ID, err)
+	}
+
+	return shift, nil
+}
+
+func scanShift(row rowScanner) (entity.Shift, error) {
+	var (
+		s           entity.Shift
+		closedAt    sql.NullTime
+		closingCash sql.NullInt64
+	)
+
+	if err := row.Scan(&s.ID, &s.Operator, &s.OpenedAt, &closedAt, &s.OpeningCash, &closingCash); err != nil {
+		return entity.Shift{}, err
+	}
+
+	if closedAt.Valid {
+		s.ClosedAt = &closedAt.Time
+	}
+	if closingCash.Valid {
+		v := entity.Money(closingCash.Int64)
+		s.ClosingCash = &v
+	}
+
+	return s, nil
+}
@@ -0,0 +1,602 @@
+package rest
+
+import (
+	"fuelStation/internal/domain/entity"
+	"fuelStation/internal/domain/service"
+	"time"
+)
+
+// Деньги передаются в копейках, объём — в десятых литра (как у счётчика)
+
+type createRefuelRequest struct {
+	DeviceID       string `json:"device_id"`
+	Grade          string `json:"grade"`
+	Mode           string `json:"mode,omitempty"`   // Amount (по умолчанию), Volume или FillUp
+	AmountPaidKop  int64  `json:"amount_paid_kop"`  // для Amount
+	Volume         int64  `json:"volume,omitempty"` // для Volume, в десятых литра
+	CounterBefore  int    `json:"counter_before"`
+	IdempotencyKey string `json:"idempotency_key,omitempty"` // повтор с тем же ключом вернёт уже созданную операцию
+	// Разбивка оплаты по способам; сумма платежей должна совпасть с суммой операции
+	Payments []paymentRequest `json:"payments,omitempty"`
+}
+
+// Итог автоотмены; started_at отсутствует, пока проходов не было
+type sweepResponse struct {
+	StartedAt    *time.Time `json:"started_at,omitempty"`
+	FinishedAt   *time.Time `json:"finished_at,omitempty"`
+	PendingTTL   string     `json:"pending_ttl"`
+	CancelledIDs []int64    `json:"cancelled_ids"`
+	Failed       int        `json:"failed"`
+	Error        string     `json:"error,omitempty"`
+}
+
+func toSweepResponse(r service.SweepResult) sweepResponse {
+	res := sweepResponse{
+		PendingTTL:   r.PendingTTL.String(),
+		CancelledIDs: r.Cancelled,
+		Failed:       r.Failed,
+		Error:        r.Error,
+	}
+	if res.CancelledIDs == nil {
+		res.CancelledIDs = []int64{}
+	}
+	if !r.StartedAt.IsZero() {
+		res.StartedAt = &r.StartedAt
+		res.FinishedAt = &r.FinishedAt
+	}
+	return res
+}
+
+type confirmRefuelRequest struct {
+	CounterAfter *int `json:"counter_after"` // фактическое показание счётчика после заправки
+}
+
+type refundRequest struct {
+	AmountKop int64  `json:"amount_kop"`
+	Method    string `json:"method"` // Cash или Card
+	IssuedBy  string `json:"issued_by"`
+}
+
+type refundResponse struct {
+	ID          int64     `json:"id"`
+	OperationID int64     `json:"operation_id"`
+	AmountKop   int64     `json:"amount_kop"`
+	Method      string    `json:"method"`
+	IssuedBy    string    `json:"issued_by"`
+	IssuedAt    time.Time `json:"issued_at"`
+}
+
+func toRefundResponse(r entity.Refund) refundResponse {
+	return refundResponse{
+		ID:          r.ID,
+		OperationID: r.OperationID,
+		AmountKop:   int64(r.Amount),
+		Method:      r.Method,
+		IssuedBy:    r.IssuedBy,
+		IssuedAt:    r.IssuedAt,
+	}
+}
+
+func toRefundResponses(refunds []entity.Refund) []refundResponse {
+	result := make([]refundResponse, 0, len(refunds))
+	for _, r := range refunds {
+		result = append(result, toRefundResponse(r))
+	}
+	return result
+}
+
+type paymentRequest struct {
+	Method      string  `json:"method"` // Cash, Card, SBP или FuelCard
+	AmountKop   int64   `json:"amount_kop"`
+	ExternalRef *string `json:"external_ref,omitempty"` // номер транзакции эквайринга, СБП или топливной карты
+}
+
+func (p paymentRequest) toEntity() entity.Payment {
+	return entity.Payment{
+		Method:      p.Method,
+		Amount:      entity.Money(p.AmountKop),
+		ExternalRef: p.ExternalRef,
+	}
+}
+
+func toPayments(reqs []paymentRequest) []entity.Payment {
+	result := make([]entity.Payment, 0, len(reqs))
+	for _, p := range reqs {
+		result = append(result, p.toEntity())
+	}
+	return result
+}
+
+type paymentResponse struct {
+	ID          int64     `json:"id"`
+	OperationID int64     `json:"operation_id"`
+	Method      string    `json:"method"`
+	AmountKop   int64     `json:"amount_kop"`
+	ExternalRef *string   `json:"external_ref,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func toPaymentResponse(p entity.Payment) paymentResponse {
+	return paymentResponse{
+		ID:          p.ID,
+		OperationID: p.OperationID,
+		Method:      p.Method,
+		AmountKop:   int64(p.Amount),
+		ExternalRef: p.ExternalRef,
+		CreatedAt:   p.CreatedAt,
+	}
+}
+
+func toPaymentResponses(payments []entity.Payment) []paymentResponse {
+	result := make([]paymentResponse, 0, len(payments))
+	for _, p := range payments {
+		result = append(result, toPaymentResponse(p))
+	}
+	return result
+}
+
+type cancelRefuelRequest struct {
+	Reason string `json:"reason"`
+}
+
+type priceRequest struct {
+	Grade            string `json:"grade"`
+	PricePerLiterKop int64  `json:"price_per_liter_kop"`
+}
+
+type counterRequest struct {
+	Value int  `json:"value"`
+	Force bool `json:"force"` // без проверки, что значение не меньше текущего
+}
+
+type refuelResponse struct {
+	ID               int64      `json:"id"`
+	DeviceID         string     `json:"device_id"`
+	Grade            string     `json:"grade"`
+	Mode             string     `json:"mode"`
+	AmountPaidKop    int64      `json:"amount_paid_kop"`
+	PaidKop          int64      `json:"paid_kop"`
+	CalculatedVolume int64      `json:"calculated_volume"`
+	DispensedVolume  *int64     `json:"dispensed_volume,omitempty"`
+	RefundDueKop     int64      `json:"refund_due_kop"`
+	RefundedKop      int64      `json:"refunded_kop"`
+	PricePerLiterKop int64      `json:"price_per_liter_kop"`
+	CounterBefore    int64      `json:"counter_before"`
+	CounterAfter     int64      `json:"counter_after"`
+	Status           string     `json:"status"`
+	CreatedAt        time.Time  `json:"created_at"`
+	ConfirmedAt      *time.Time `json:"confirmed_at,omitempty"`
+	CancelledAt      *time.Time `json:"cancelled_at,omitempty"`
+	CancelReason     *string    `json:"cancel_reason,omitempty"`
+	IdempotencyKey   *string    `json:"idempotency_key,omitempty"`
+}
+
+func toRefuelResponse(o entity.RefuelOperation) refuelResponse {
+	return refuelResponse{
+		ID:               o.ID,
+		DeviceID:         o.DeviceID,
+		Grade:            o.GradeCode,
+		Mode:             o.Mode,
+		AmountPaidKop:    int64(o.AmountPaid),
+		PaidKop:          int64(o.Paid),
+		CalculatedVolume: int64(o.CalculatedLiters),
+		DispensedVolume:  (*int64)(o.DispensedLiters),
+		RefundDueKop:     int64(o.RefundDue),
+		RefundedKop:      int64(o.Refunded),
+		PricePerLiterKop: int64(o.PricePerLiter),
+		CounterBefore:    o.CounterBefore,
+		CounterAfter:     o.CounterAfter,
+		Status:           o.Status,
+		CreatedAt:        o.CreatedAt,
+		ConfirmedAt:      o.ConfirmedAt,
+		CancelledAt:      o.CancelledAt,
+		CancelReason:     o.CancelReason,
+		IdempotencyKey:   o.IdempotencyKey,
+	}
+}
+
+func toRefuelResponses(ops []entity.RefuelOperation) []refuelResponse {
+	result := make([]refuelResponse, 0, len(ops))
+	for _, o := range ops {
+		result = append(result, toRefuelResponse(o))
+	}
+	return result
+}
+
+type priceResponse struct {
+	ID               int64     `json:"id"`
+	Grade            string    `json:"grade"`
+	PricePerLiterKop int64     `json:"price_per_liter_kop"`
+	CreatedAt        time.Time `json:"created_at"`
+	IsActive         bool      `json:"is_active"`
+}
+
+func toPriceResponse(p entity.FuelPrice) priceResponse {
+	return priceResponse{
+		ID:               p.ID,
+		Grade:            p.GradeCode,
+		PricePerLiterKop: int64(p.PricePerLiter),
+		CreatedAt:        p.CreatedAt,
+		IsActive:         p.IsActive,
+	}
+}
+
+func toPriceResponses(prices []entity.FuelPrice) []priceResponse {
+	result := make([]priceResponse, 0, len(prices))
+	for _, p := range prices {
+		result = append(result, toPriceResponse(p))
+	}
+	return result
+}
+
+// Цена с периодом действия; effective_to отсутствует у действующей цены
+type priceIntervalResponse struct {
+	priceResponse
+	EffectiveFrom time.Time  `json:"effective_from"`
+	EffectiveTo   *time.Time `json:"effective_to,omitempty"`
+}
+
+func toPriceIntervalResponses(intervals []entity.PriceInterval) []priceIntervalResponse {
+	result := make([]priceIntervalResponse, 0, len(intervals))
+	for _, i := range intervals {
+		result = append(result, priceIntervalResponse{
+			priceResponse: toPriceResponse(i.Price),
+			EffectiveFrom: i.From,
+			EffectiveTo:   i.To,
+		})
+	}
+	return result
+}
+
+type schedulePriceRequest struct {
+	Grade            string    `json:"grade"`
+	PricePerLiterKop int64     `json:"price_per_liter_kop"`
+	EffectiveFrom    time.Time `json:"effective_from"`
+}
+
+type scheduledPriceResponse struct {
+	ID               int64      `json:"id"`
+	Grade            string     `json:"grade"`
+	PricePerLiterKop int64      `json:"price_per_liter_kop"`
+	EffectiveFrom    time.Time  `json:"effective_from"`
+	Status           string     `json:"status"`
+	CreatedAt        time.Time  `json:"created_at"`
+	AppliedAt        *time.Time `json:"applied_at,omitempty"`
+	CancelledAt      *time.Time `json:"cancelled_at,omitempty"`
+	FailedAt         *time.Time `json:"failed_at,omitempty"`
+	FailureReason    *string    `json:"failure_reason,omitempty"`
+}
+
+func toScheduledPriceResponse(p entity.ScheduledPrice) scheduledPriceResponse {
+	return scheduledPriceResponse{
+		ID:               p.ID,
+		Grade:            p.GradeCode,
+		PricePerLiterKop: int64(p.PricePerLiter),
+		EffectiveFrom:    p.EffectiveFrom,
+		Status:           p.Status,
+		CreatedAt:        p.CreatedAt,
+		AppliedAt:        p.AppliedAt,
+		CancelledAt:      p.CancelledAt,
+		FailedAt:         p.FailedAt,
+		FailureReason:    p.FailureReason,
+	}
+}
+
+func toScheduledPriceResponses(prices []entity.ScheduledPrice) []scheduledPriceResponse {
+	result := make([]scheduledPriceResponse, 0, len(prices))
+	for _, p := range prices {
+		result = append(result, toScheduledPriceResponse(p))
+	}
+	return result
+}
+
+type gradeRequest struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+}
+
+type gradeResponse struct {
+	Code      string    `json:"code"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func toGradeResponse(g entity.FuelGrade) gradeResponse {
+	return gradeResponse{
+		Code:      g.Code,
+		Name:      g.Name,
+		CreatedAt: g.CreatedAt,
+	}
+}
+
+func toGradeResponses(grades []entity.FuelGrade) []gradeResponse {
+	result := make([]gradeResponse, 0, len(grades))
+	for _, g := range grades {
+		result = append(result, toGradeResponse(g))
+	}
+	return result
+}
+
+type deviceRequest struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type deviceStateRequest struct {
+	IsActive bool `json:"is_active"`
+}
+
+type deviceResponse struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	IsActive  bool      `json:"is_active"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func toDeviceResponse(d entity.Device) deviceResponse {
+	return deviceResponse{
+		ID:        d.ID,
+		Name:      d.Name,
+		IsActive:  d.IsActive,
+		CreatedAt: d.CreatedAt,
+	}
+}
+
+func toDeviceResponses(devices []entity.Device) []deviceResponse {
+	result := make([]deviceResponse, 0, len(devices))
+	for _, d := range devices {
+		result = append(result, toDeviceResponse(d))
+	}
+	return result
+}
+
+type counterResponse struct {
+	DeviceID  string    `json:"device_id"`
+	Value     int64     `json:"value"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Version   int64     `json:"version"`
+}
+
+func toCounterResponse(c entity.CounterState) counterResponse {
+	return counterResponse{
+		DeviceID:  c.DeviceID,
+		Value:     c.CurrentValue,
+		UpdatedAt: c.UpdatedAt,
+		Version:   c.Version,
+	}
+}
+
+type statisticsResponse struct {
+	TotalOperations  int64                     `json:"total_operations"`
+	TotalRevenueKop  int64                     `json:"total_revenue_kop"`
+	TotalRefundedKop int64                     `json:"total_refunded_kop"`
+	OutstandingKop   int64                     `json:"outstanding_refunds_kop"`
+	TotalVolume      int64                     `json:"total_volume"`
+	AverageVolume    int64                     `json:"average_volume"`
+	AverageAmountKop int64                     `json:"average_amount_kop"`
+	ConfirmedCount   int64                     `json:"confirmed_count"`
+	PartialCount     int64                     `json:"partially_fulfilled_count"`
+	CancelledCount   int64                     `json:"cancelled_count"`
+	PendingCount     int64                     `json:"pending_count"`
+	StartDate        time.Time                 `json:"start_date"`
+	EndDate          time.Time                 `json:"end_date"`
+	ByGrade          []gradeStatisticsResponse `json:"by_grade"`
+
+	ByPaymentMethod []paymentMethodStatisticsResponse `json:"by_payment_method"`
+	UnallocatedKop  int64                             `json:"unallocated_kop"` // сумма операций без разнесения по платежам
+}
+
+type paymentMethodStatisticsResponse struct {
+	Method       string `json:"method"`
+	PaymentCount int64  `json:"payment_count"`
+	PaidKop      int64  `json:"paid_kop"`
+	RefundedKop  int64  `json:"refunded_kop"`
+	NetKop       int64  `json:"net_kop"`
+}
+
+type gradeStatisticsResponse struct {
+	Grade           string `json:"grade"`
+	ConfirmedCount  int64  `json:"confirmed_count"`
+	TotalRevenueKop int64  `json:"total_revenue_kop"`
+	TotalVolume     int64  `json:"total_volume"`
+}
+
+func toStatisticsResponse(s service.RefuelStatistics) statisticsResponse {
+	return statisticsResponse{
+		TotalOperations:  s.TotalOperations,
+		TotalRevenueKop:  int64(s.TotalRevenue),
+		TotalRefundedKop: int64(s.TotalRefunded),
+		OutstandingKop:   int64(s.OutstandingRefunds),
+		TotalVolume:      int64(s.TotalLiters),
+		AverageVolume:    int64(s.AverageLiters),
+		AverageAmountKop: int64(s.AverageAmount),
+		ConfirmedCount:   s.ConfirmedCount,
+		PartialCount:     s.PartialCount,
+		CancelledCount:   s.CancelledCount,
+		PendingCount:     s.PendingCount,
+		StartDate:        s.StartDate,
+		EndDate:          s.EndDate,
+		ByGrade:          toGradeStatisticsResponses(s.ByGrade),
+		ByPaymentMethod:  toPaymentMethodStatisticsResponses(s.ByPaymentMethod),
+		UnallocatedKop:   int64(s.Unallocated),
+	}
+}
+
+func toPaymentMethodStatisticsResponses(methods []service.PaymentMethodStatistics) []paymentMethodStatisticsResponse {
+	result := make([]paymentMethodStatisticsResponse, 0, len(methods))
+	for _, m := range methods {
+		result = append(result, paymentMethodStatisticsResponse{
+			Method:       m.Method,
+			PaymentCount: m.PaymentCount,
+			PaidKop:      int64(m.Paid),
+			RefundedKop:  int64(m.Refunded),
+			NetKop:       int64(m.Net),
+		})
+	}
+	return result
+}
+
+func toGradeStatisticsResponses(grades []service.GradeStatistics) []gradeStatisticsResponse {
+	result := make([]gradeStatisticsResponse, 0, len(grades))
+	for _, g := range grades {
+		result = append(result, gradeStatisticsResponse{
+			Grade:           g.GradeCode,
+			ConfirmedCount:  g.ConfirmedCount,
+			TotalRevenueKop: int64(g.TotalRevenue),
+			TotalVolume:     int64(g.TotalLiters),
+		})
+	}
+	return result
+}
+
+type openShiftRequest struct {
+	Operator       string `json:"operator"`
+	OpeningCashKop int64  `json:"opening_cash_kop"` // наличные в кассе на открытии
+}
+
+type closeShiftRequest struct {
+	ClosingCashKop int64 `json:"closing_cash_kop"` // пересчитанные наличные на закрытии
+}
+
+type shiftResponse struct {
+	ID             int64                  `json:"id"`
+	Operator       string                 `json:"operator"`
+	OpenedAt       time.Time              `json:"opened_at"`
+	ClosedAt       *time.Time             `json:"closed_at,omitempty"`
+	OpeningCashKop int64                  `json:"opening_cash_kop"`
+	ClosingCashKop *int64                 `json:"closing_cash_kop,omitempty"`
+	Counters       []shiftCounterResponse `json:"counters,omitempty"`
+}
+
+type shiftCounterResponse struct {
+	DeviceID   string `json:"device_id"`
+	StartValue int64  `json:"start_value"`
+	EndValue   *int64 `json:"end_value,omitempty"`
+}
+
+func toShiftResponse(s entity.Shift) shiftResponse {
+	res := shiftResponse{
+		ID:             s.ID,
+		Operator:       s.Operator,
+		OpenedAt:       s.OpenedAt,
+		ClosedAt:       s.ClosedAt,
+		OpeningCashKop: int64(s.OpeningCash),
+		ClosingCashKop: (*int64)(s.ClosingCash),
+	}
+	for _, c := range s.Counters {
+		res.Counters = append(res.Counters, shiftCounterResponse{
+			DeviceID:   c.DeviceID,
+			StartValue: c.StartValue,
+			EndValue:   c.EndValue,
+		})
+	}
+	return res
+}
+
+func toShiftResponses(shifts []entity.Shift) []shiftResponse {
+	result := make([]shiftResponse, 0, len(shifts))
+	for _, s := range shifts {
+		result = append(result, toShiftResponse(s))
+	}
+	return result
+}
+
+// Отчёт по смене; cash_discrepancy_kop есть только у закрытой смены
+type shiftReportResponse struct {
+	Shift              shiftResponse                     `json:"shift"`
+	To                 time.Time                         `json:"to"`
+	Statistics         statisticsResponse                `json:"statistics"`
+	Devices            []shiftDeviceReportResponse       `json:"devices"`
+	ByPaymentMethod    []paymentMethodStatisticsResponse `json:"by_payment_method"` // деньги, прошедшие через кассу за смену
+	CounterDelta       int64                             `json:"counter_delta"`
+	SoldVolume         int64                             `json:"sold_volume"`
+	Discrepancy        int64                             `json:"discrepancy"`
+	ExpectedCashKop    int64                             `json:"expected_cash_kop"`
+	CashDiscrepancyKop *int64                            `json:"cash_discrepancy_kop,omitempty"`
+}
+
+type shiftDeviceReportResponse struct {
+	DeviceID     string `json:"device_id"`
+	CounterStart int64  `json:"counter_start"`
+	CounterEnd   int64  `json:"counter_end"`
+	CounterDelta int64  `json:"counter_delta"`
+	SoldVolume   int64  `json:"sold_volume"`
+	Discrepancy  int64  `json:"discrepancy"`
+}
+
+func toShiftReportResponse(r service.ShiftReport) shiftReportResponse {
+	res := shiftReportResponse{
+		Shift:              toShiftResponse(r.Shift),
+		To:                 r.To,
+		Statistics:         toStatisticsResponse(r.Statistics),
+		Devices:            make([]shiftDeviceReportResponse, 0, len(r.Devices)),
+		ByPaymentMethod:    toPaymentMethodStatisticsResponses(r.ByPaymentMethod),
+		CounterDelta:       int64(r.CounterDelta),
+		SoldVolume:         int64(r.SoldLiters),
+		Discrepancy:        int64(r.Discrepancy),
+		ExpectedCashKop:    int64(r.ExpectedCash),
+		CashDiscrepancyKop: (*int64)(r.CashDiscrepancy),
+	}
+	for _, d := range r.Devices {
+		res.Devices = append(res.Devices, shiftDeviceReportResponse{
+			DeviceID:     d.DeviceID,
+			CounterStart: d.CounterStart,
+			CounterEnd:   d.CounterEnd,
+			CounterDelta: int64(d.CounterDelta),
+			SoldVolume:   int64(d.SoldLiters),
+			Discrepancy:  int64(d.Discrepancy),
+		})
+	}
+	return res
+}
+
+type errorResponse struct {
+	Error string          `json:"error"`
+	Limit *limitViolation `json:"limit,omitempty"` // какое правило лимитов нарушено
+}
+
+type limitViolation struct {
+	Rule      string `json:"rule"`
+	Value     int64  `json:"value"`
+	Threshold int64  `json:"threshold"`
+	Unit      string `json:"unit"`
+}
+
+type limitsRequest struct {
+	MinPricePerLiterKop   int64 `json:"min_price_per_liter_kop"`
+	MaxPricePerLiterKop   int64 `json:"max_price_per_liter_kop"`
+	MinAmountPaidKop      int64 `json:"min_amount_paid_kop"`
+	MaxAmountPaidKop      int64 `json:"max_amount_paid_kop"`
+	MaxPriceChangePercent int64 `json:"max_price_change_percent"`
+}
+
+func (r limitsRequest) toEntity() entity.Limits {
+	return entity.Limits{
+		MinPricePerLiter:      entity.Money(r.MinPricePerLiterKop),
+		MaxPricePerLiter:      entity.Money(r.MaxPricePerLiterKop),
+		MinAmountPaid:         entity.Money(r.MinAmountPaidKop),
+		MaxAmountPaid:         entity.Money(r.MaxAmountPaidKop),
+		MaxPriceChangePercent: r.MaxPriceChangePercent,
+	}
+}
+
+type limitsResponse struct {
+	limitsRequest
+	UpdatedAt *time.Time `json:"updated_at,omitempty"` // пусто, пока действуют значения по умолчанию
+}
+
+func toLimitsResponse(l entity.Limits) limitsResponse {
+	resp := limitsResponse{
+		limitsRequest: limitsRequest{
+			MinPricePerLiterKop:   int64(l.MinPricePerLiter),
+			MaxPricePerLiterKop:   int64(l.MaxPricePerLiter),
+			MinAmountPaidKop:      int64(l.MinAmountPaid),
+			MaxAmountPaidKop:      int64(l.MaxAmountPaid),
+			MaxPriceChangePercent: l.MaxPriceChangePercent,
+		},
+	}
+	if !l.UpdatedAt.IsZero() {
+		resp.UpdatedAt = &l.UpdatedAt
+	}
+	return resp
+}
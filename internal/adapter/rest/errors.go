@@ -0,0 +1,80 @@
+package rest
+
+import (
+	"errors"
+	"fuelStation/internal/domain/service"
+	"net/http"
+)
+
+var (
+	errBadRequest    = errors.New("bad request")
+	errUnauthorized  = errors.New("admin token required")
+	errAdminDisabled = errors.New("admin API is disabled: admin token is not configured")
+)
+
+// Соответствие ошибок сервисов HTTP статусам
+var errorStatuses = []struct {
+	err    error
+	status int
+}{
+	{errBadRequest, http.StatusBadRequest},
+	{errUnauthorized, http.StatusUnauthorized},
+	{errAdminDisabled, http.StatusForbidden},
+
+	{service.ErrNotFoundOper, http.StatusNotFound},
+	{service.ErrNotFoundPrice, http.StatusNotFound},
+	{service.ErrNotFoundOldPrice, http.StatusNotFound},
+	{service.ErrNotFoundCounter, http.StatusNotFound},
+	{service.ErrNotFoundGrade, http.StatusNotFound},
+	{service.ErrNotFoundDevice, http.StatusNotFound},
+	{service.ErrNotFoundScheduledPrice, http.StatusNotFound},
+	{service.ErrNotFoundShift, http.StatusNotFound},
+
+	{service.ErrPriceCanNotBeNegative, http.StatusUnprocessableEntity},
+	{service.ErrPriceTooHigh, http.StatusUnprocessableEntity},
+	{service.ErrPriceTooLow, http.StatusUnprocessableEntity},
+	{service.ErrPriceChangeTooLarge, http.StatusUnprocessableEntity},
+	{service.ErrCounterCanNotBeNegative, http.StatusUnprocessableEntity},
+	{service.ErrNewValueCanNotBeSmallerThanOld, http.StatusUnprocessableEntity},
+	{service.ErrAmountCanNotBeNegative, http.StatusUnprocessableEntity},
+	{service.ErrAmountTooHigh, http.StatusUnprocessableEntity},
+	{service.ErrAmountTooLow, http.StatusUnprocessableEntity},
+	{service.ErrInvalidLimits, http.StatusUnprocessableEntity},
+	{service.ErrInvalidGrade, http.StatusUnprocessableEntity},
+	{service.ErrInvalidDevice, http.StatusUnprocessableEntity},
+	{service.ErrScheduleInPast, http.StatusUnprocessableEntity},
+	{service.ErrInvalidIdempotencyKey, http.StatusUnprocessableEntity},
+	{service.ErrDispensedMoreThanPaid, http.StatusUnprocessableEntity},
+	{service.ErrInvalidRefund, http.StatusUnprocessableEntity},
+	{service.ErrRefundExceedsDue, http.StatusUnprocessableEntity},
+	{service.ErrInvalidRefuelMode, http.StatusUnprocessableEntity},
+	{service.ErrVolumeMustBePositive, http.StatusUnprocessableEntity},
+	{service.ErrCounterReadingRequired, http.StatusUnprocessableEntity},
+	{service.ErrNothingDispensed, http.StatusUnprocessableEntity},
+	{service.ErrInvalidPayment, http.StatusUnprocessableEntity},
+	{service.ErrPaymentExceedsAmount, http.StatusUnprocessableEntity},
+	{service.ErrPaymentsMismatchAmount, http.StatusUnprocessableEntity},
+	{service.ErrInvalidShift, http.StatusUnprocessableEntity},
+
+	{service.ErrInvalidOperationStatus, http.StatusConflict},
+	{service.ErrCounterWasChangedDuringRefuelCreation, http.StatusConflict},
+	{service.ErrTryUseChangePrice, http.StatusConflict},
+	{service.ErrCounterVersionConflict, http.StatusConflict},
+	{service.ErrGradeAlreadyExists, http.StatusConflict},
+	{service.ErrDeviceAlreadyExists, http.StatusConflict},
+	{service.ErrDeviceInactive, http.StatusConflict},
+	{service.ErrShiftAlreadyOpen, http.StatusConflict},
+	{service.ErrNoOpenShift, http.StatusConflict},
+	{service.ErrScheduledPriceNotPending, http.StatusConflict},
+	{service.ErrIdempotencyKeyMismatch, http.StatusConflict},
+}
+
+// HTTP статус для ошибки; неизвестные ошибки — 500
+func statusFor(err error) int {
+	for _, e := range errorStatuses {
+		if errors.Is(err, e.err) {
+			return e.status
+		}
+	}
+	return http.StatusInternalServerError
+}
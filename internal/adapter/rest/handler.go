@@ -0,0 +1,776 @@
+package rest
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"fuelStation/internal/domain/entity"
+	"fuelStation/internal/domain/service"
+	"fuelStation/internal/usecase"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Период по умолчанию для истории и статистики
+const defaultPeriod = 24 * time.Hour
+
+type Handler struct {
+	uc         *usecase.UseCase
+	adminToken string // токен для административных запросов; пусто — они запрещены
+}
+
+func NewHandler(uc *usecase.UseCase, adminToken string) *Handler {
+	return &Handler{
+		uc:         uc,
+		adminToken: adminToken,
+	}
+}
+
+// Маршруты API
+func (h *Handler) Routes() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("POST /api/refuels", h.createRefuel)
+	mux.HandleFunc("GET /api/refuels", h.refuelHistory)
+	mux.HandleFunc("GET /api/refuels/pending", h.pendingRefuels)
+	mux.HandleFunc("GET /api/refuels/sweep", h.lastSweep)
+	mux.HandleFunc("POST /api/refuels/sweep", h.sweepRefuels)
+	mux.HandleFunc("GET /api/refuels/{id}", h.getRefuel)
+	mux.HandleFunc("POST /api/refuels/{id}/confirm", h.confirmRefuel)
+	mux.HandleFunc("POST /api/refuels/{id}/cancel", h.cancelRefuel)
+	mux.HandleFunc("GET /api/refuels/{id}/refunds", h.listRefunds)
+	mux.HandleFunc("POST /api/refuels/{id}/refunds", h.issueRefund)
+	mux.HandleFunc("GET /api/refunds/outstanding", h.outstandingRefunds)
+	mux.HandleFunc("GET /api/refuels/{id}/payments", h.listPayments)
+	mux.HandleFunc("POST /api/refuels/{id}/payments", h.addPayment)
+
+	mux.HandleFunc("GET /api/statistics", h.statistics)
+
+	mux.HandleFunc("GET /api/shifts", h.listShifts)
+	mux.HandleFunc("POST /api/shifts", h.openShift)
+	mux.HandleFunc("GET /api/shifts/current", h.currentShift)
+	mux.HandleFunc("POST /api/shifts/current/close", h.closeShift)
+	mux.HandleFunc("GET /api/shifts/{id}/report", h.shiftReport)
+
+	mux.HandleFunc("GET /api/grades", h.listGrades)
+	mux.HandleFunc("POST /api/grades", h.createGrade)
+
+	mux.HandleFunc("GET /api/prices", h.activePrices)
+	mux.HandleFunc("GET /api/price", h.getPrice)
+	mux.HandleFunc("POST /api/price", h.initPrice)
+	mux.HandleFunc("PUT /api/price", h.changePrice)
+	mux.HandleFunc("GET /api/price/history", h.priceHistory)
+	mux.HandleFunc("GET /api/price/at", h.priceAt)
+	mux.HandleFunc("GET /api/price/intervals", h.priceIntervals)
+	mux.HandleFunc("GET /api/price/schedule", h.scheduledPrices)
+	mux.HandleFunc("POST /api/price/schedule", h.schedulePrice)
+	mux.HandleFunc("POST /api/price/schedule/{id}/cancel", h.cancelScheduledPrice)
+
+	mux.HandleFunc("GET /api/devices", h.listDevices)
+	mux.HandleFunc("POST /api/devices", h.createDevice)
+	mux.HandleFunc("GET /api/devices/{id}", h.getDevice)
+	mux.HandleFunc("PATCH /api/devices/{id}", h.updateDevice)
+
+	mux.HandleFunc("GET /api/devices/{id}/counter", h.getCounter)
+	mux.HandleFunc("PUT /api/devices/{id}/counter", h.updateCounter)
+
+	mux.HandleFunc("GET /api/limits", h.getLimits)
+	mux.HandleFunc("PUT /api/limits", h.adminOnly(h.updateLimits))
+
+	return mux
+}
+
+// Создание заправки: на сумму, на объём или до полного бака (по полю mode)
+func (h *Handler) createRefuel(w http.ResponseWriter, r *http.Request) {
+	var req createRefuelRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	mode, err := service.ParseRefuelMode(req.Mode)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var op entity.RefuelOperation
+	switch mode {
+	case service.RefuelModeVolume:
+		op, err = h.uc.CreateRefuelByVolume(r.Context(), req.DeviceID, req.Grade, entity.Volume(req.Volume), req.CounterBefore, req.IdempotencyKey, toPayments(req.Payments))
+	case service.RefuelModeFillUp:
+		op, err = h.uc.CreateFillUp(r.Context(), req.DeviceID, req.Grade, req.CounterBefore, req.IdempotencyKey)
+	default:
+		op, err = h.uc.CreateRefuel(r.Context(), req.DeviceID, req.Grade, entity.Money(req.AmountPaidKop), req.CounterBefore, req.IdempotencyKey, toPayments(req.Payments))
+	}
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, toRefuelResponse(op))
+}
+
+// Подтверждение заправки. С телом {"counter_after": N} — по фактическому показанию счётчика
+func (h *Handler) confirmRefuel(w http.ResponseWriter, r *http.Request) {
+	var req confirmRefuelRequest
+	if err := decodeOptionalJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var (
+		op  entity.RefuelOperation
+		err error
+	)
+	if req.CounterAfter != nil {
+		op, err = h.uc.ConfirmRefuelWithCounter(r.Context(), r.PathValue("id"), *req.CounterAfter)
+	} else {
+		op, err = h.uc.ConfirmRefuel(r.Context(), r.PathValue("id"))
+	}
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toRefuelResponse(op))
+}
+
+// Отмена заправки
+func (h *Handler) cancelRefuel(w http.ResponseWriter, r *http.Request) {
+	var req cancelRefuelRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	op, err := h.uc.CancelRefuel(r.Context(), r.PathValue("id"), req.Reason)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toRefuelResponse(op))
+}
+
+// Операция по id
+func (h *Handler) getRefuel(w http.ResponseWriter, r *http.Request) {
+	op, err := h.uc.GetRefuelById(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toRefuelResponse(op))
+}
+
+// Незавершенные заправки: ?device_id=
+func (h *Handler) pendingRefuels(w http.ResponseWriter, r *http.Request) {
+	ops, err := h.uc.GetPendingOperations(r.Context(), r.URL.Query().Get("device_id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toRefuelResponses(ops))
+}
+
+// Возвраты по операции
+func (h *Handler) listRefunds(w http.ResponseWriter, r *http.Request) {
+	refunds, err := h.uc.GetRefunds(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toRefundResponses(refunds))
+}
+
+// Выдача возврата по операции
+func (h *Handler) issueRefund(w http.ResponseWriter, r *http.Request) {
+	var req refundRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	refund, err := h.uc.IssueRefund(r.Context(), r.PathValue("id"), entity.Money(req.AmountKop), req.Method, req.IssuedBy)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, toRefundResponse(refund))
+}
+
+// Платежи по операции
+func (h *Handler) listPayments(w http.ResponseWriter, r *http.Request) {
+	payments, err := h.uc.GetPayments(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toPaymentResponses(payments))
+}
+
+// Приём платежа по операции (например, оплата FillUp после подтверждения)
+func (h *Handler) addPayment(w http.ResponseWriter, r *http.Request) {
+	var req paymentRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	payment, err := h.uc.AddPayment(r.Context(), r.PathValue("id"), req.toEntity())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, toPaymentResponse(payment))
+}
+
+// Операции с невыданным возвратом: ?device_id=
+func (h *Handler) outstandingRefunds(w http.ResponseWriter, r *http.Request) {
+	ops, err := h.uc.GetOutstandingRefunds(r.Context(), r.URL.Query().Get("device_id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toRefuelResponses(ops))
+}
+
+// Итог последней автоотмены зависших операций
+func (h *Handler) lastSweep(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, toSweepResponse(h.uc.GetLastSweep()))
+}
+
+// Отменить зависшие операции сейчас
+func (h *Handler) sweepRefuels(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, toSweepResponse(h.uc.ExpirePendingOperations(r.Context())))
+}
+
+// История заправок: ?from=&to=&device_id=&status=&grade=
+func (h *Handler) refuelHistory(w http.ResponseWriter, r *http.Request) {
+	from, to, err := parsePeriod(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	q := r.URL.Query()
+	ops, err := h.uc.GetRefuelHistory(r.Context(), from, to, q.Get("device_id"), q.Get("status"), q.Get("grade"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toRefuelResponses(ops))
+}
+
+// Смены, открытые за период: ?from=&to=&operator=
+func (h *Handler) listShifts(w http.ResponseWriter, r *http.Request) {
+	from, to, err := parsePeriod(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	shifts, err := h.uc.GetShifts(r.Context(), from, to, r.URL.Query().Get("operator"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toShiftResponses(shifts))
+}
+
+// Открытие смены
+func (h *Handler) openShift(w http.ResponseWriter, r *http.Request) {
+	var req openShiftRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	shift, err := h.uc.OpenShift(r.Context(), req.Operator, entity.Money(req.OpeningCashKop))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, toShiftResponse(shift))
+}
+
+// Отчёт по открытой смене на текущий момент
+func (h *Handler) currentShift(w http.ResponseWriter, r *http.Request) {
+	report, err := h.uc.GetCurrentShift(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toShiftReportResponse(report))
+}
+
+// Закрытие смены с Z-отчётом
+func (h *Handler) closeShift(w http.ResponseWriter, r *http.Request) {
+	var req closeShiftRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	report, err := h.uc.CloseShift(r.Context(), entity.Money(req.ClosingCashKop))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toShiftReportResponse(report))
+}
+
+// Отчёт по смене
+func (h *Handler) shiftReport(w http.ResponseWriter, r *http.Request) {
+	report, err := h.uc.GetShiftReport(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toShiftReportResponse(report))
+}
+
+// Статистика за период: ?from=&to=
+func (h *Handler) statistics(w http.ResponseWriter, r *http.Request) {
+	from, to, err := parsePeriod(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	stats, err := h.uc.GetStatistics(r.Context(), from, to)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toStatisticsResponse(stats))
+}
+
+// Запланированные цены: ?grade=&status= (по умолчанию только ожидающие)
+func (h *Handler) scheduledPrices(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	status := service.ScheduledPriceStatusPending
+	if q.Has("status") {
+		status = q.Get("status")
+	}
+
+	prices, err := h.uc.GetScheduledPrices(r.Context(), q.Get("grade"), status)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toScheduledPriceResponses(prices))
+}
+
+// Планирование цены
+func (h *Handler) schedulePrice(w http.ResponseWriter, r *http.Request) {
+	var req schedulePriceRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	price, err := h.uc.SchedulePrice(r.Context(), req.Grade, entity.Money(req.PricePerLiterKop), req.EffectiveFrom)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, toScheduledPriceResponse(price))
+}
+
+// Отмена запланированной цены
+func (h *Handler) cancelScheduledPrice(w http.ResponseWriter, r *http.Request) {
+	price, err := h.uc.CancelScheduledPrice(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toScheduledPriceResponse(price))
+}
+
+// Марки топлива
+func (h *Handler) listGrades(w http.ResponseWriter, r *http.Request) {
+	grades, err := h.uc.GetFuelGrades(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toGradeResponses(grades))
+}
+
+// Добавление марки топлива
+func (h *Handler) createGrade(w http.ResponseWriter, r *http.Request) {
+	var req gradeRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	grade, err := h.uc.CreateFuelGrade(r.Context(), req.Code, req.Name)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, toGradeResponse(grade))
+}
+
+// Активные цены всех марок
+func (h *Handler) activePrices(w http.ResponseWriter, r *http.Request) {
+	prices, err := h.uc.GetActivePrices(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toPriceResponses(prices))
+}
+
+// Активная цена марки: ?grade=
+func (h *Handler) getPrice(w http.ResponseWriter, r *http.Request) {
+	grade := r.URL.Query().Get("grade")
+	if grade == "" {
+		writeError(w, fmt.Errorf("%w: не указана марка топлива (grade)", errBadRequest))
+		return
+	}
+
+	price, err := h.uc.GetPricePerLiter(r.Context(), grade)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toPriceResponse(price))
+}
+
+// Установка цены впервые
+func (h *Handler) initPrice(w http.ResponseWriter, r *http.Request) {
+	var req priceRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	price, err := h.uc.InitPricePerLiter(r.Context(), req.Grade, entity.Money(req.PricePerLiterKop))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, toPriceResponse(price))
+}
+
+// Смена цены
+func (h *Handler) changePrice(w http.ResponseWriter, r *http.Request) {
+	var req priceRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	price, err := h.uc.ChangePricePerLiter(r.Context(), req.Grade, entity.Money(req.PricePerLiterKop))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toPriceResponse(price))
+}
+
+// История цен: ?from=&to=&grade=
+func (h *Handler) priceHistory(w http.ResponseWriter, r *http.Request) {
+	from, to, err := parsePeriod(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	prices, err := h.uc.GetPriceHistory(r.Context(), from, to, r.URL.Query().Get("grade"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toPriceResponses(prices))
+}
+
+// Цена марки в момент времени: ?grade=&at= (RFC3339)
+func (h *Handler) priceAt(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	grade := q.Get("grade")
+	if grade == "" {
+		writeError(w, fmt.Errorf("%w: не указана марка топлива (grade)", errBadRequest))
+		return
+	}
+
+	at, err := time.Parse(time.RFC3339, q.Get("at"))
+	if err != nil {
+		writeError(w, fmt.Errorf("%w: некорректный at", errBadRequest))
+		return
+	}
+
+	price, err := h.uc.GetPriceAt(r.Context(), grade, at)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toPriceResponse(price))
+}
+
+// Цены с периодами действия: ?from=&to=&grade=
+func (h *Handler) priceIntervals(w http.ResponseWriter, r *http.Request) {
+	from, to, err := parsePeriod(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	intervals, err := h.uc.GetPriceIntervals(r.Context(), from, to, r.URL.Query().Get("grade"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toPriceIntervalResponses(intervals))
+}
+
+// Колонки
+func (h *Handler) listDevices(w http.ResponseWriter, r *http.Request) {
+	devices, err := h.uc.GetDevices(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toDeviceResponses(devices))
+}
+
+// Регистрация колонки
+func (h *Handler) createDevice(w http.ResponseWriter, r *http.Request) {
+	var req deviceRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	device, err := h.uc.CreateDevice(r.Context(), req.ID, req.Name)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, toDeviceResponse(device))
+}
+
+// Колонка по id
+func (h *Handler) getDevice(w http.ResponseWriter, r *http.Request) {
+	device, err := h.uc.GetDevice(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toDeviceResponse(device))
+}
+
+// Включение/выключение колонки
+func (h *Handler) updateDevice(w http.ResponseWriter, r *http.Request) {
+	var req deviceStateRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	device, err := h.uc.SetDeviceActive(r.Context(), r.PathValue("id"), req.IsActive)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toDeviceResponse(device))
+}
+
+// Текущее значение счётчика колонки
+func (h *Handler) getCounter(w http.ResponseWriter, r *http.Request) {
+	counter, err := h.uc.GetCurrent(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toCounterResponse(counter))
+}
+
+// Смена значения счётчика колонки (force — без проверки на уменьшение)
+func (h *Handler) updateCounter(w http.ResponseWriter, r *http.Request) {
+	var req counterRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var (
+		counter entity.CounterState
+		err     error
+	)
+	if req.Force {
+		counter, err = h.uc.UpdateCounter(r.Context(), r.PathValue("id"), req.Value)
+	} else {
+		counter, err = h.uc.UpdateCounterDuringRefuel(r.Context(), r.PathValue("id"), req.Value)
+	}
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toCounterResponse(counter))
+}
+
+// Действующие лимиты
+func (h *Handler) getLimits(w http.ResponseWriter, r *http.Request) {
+	limits, err := h.uc.GetLimits(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toLimitsResponse(limits))
+}
+
+// Изменение лимитов администратором
+func (h *Handler) updateLimits(w http.ResponseWriter, r *http.Request) {
+	var req limitsRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	limits, err := h.uc.UpdateLimits(r.Context(), req.toEntity())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toLimitsResponse(limits))
+}
+
+// Пропускает запрос только с токеном администратора в заголовке Authorization: Bearer <токен>.
+// Без настроенного токена административные запросы запрещены: лимиты тогда меняются через CLI
+func (h *Handler) adminOnly(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if h.adminToken == "" {
+			writeError(w, errAdminDisabled)
+			return
+		}
+
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(h.adminToken)) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeError(w, errUnauthorized)
+			return
+		}
+
+		next(w, r)
+	}
+}
+
+// Период из query параметров from/to (RFC3339), по умолчанию последние сутки
+func parsePeriod(r *http.Request) (time.Time, time.Time, error) {
+	to := time.Now()
+	from := to.Add(-defaultPeriod)
+
+	q := r.URL.Query()
+	if v := q.Get("from"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("%w: некорректный from", errBadRequest)
+		}
+		from = t
+	}
+	if v := q.Get("to"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("%w: некорректный to", errBadRequest)
+		}
+		to = t
+	}
+
+	return from, to, nil
+}
+
+func decodeJSON(r *http.Request, dst any) error {
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(dst); err != nil {
+		return fmt.Errorf("%w: %v", errBadRequest, err)
+	}
+	return nil
+}
+
+// Как decodeJSON, но пустое тело допустимо
+func decodeOptionalJSON(r *http.Request, dst any) error {
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(dst); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("%w: %v", errBadRequest, err)
+	}
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		log.Printf("⚠️ Ошибка записи ответа: %v\n", err)
+	}
+}
+
+// Пишет ошибку с подходящим статусом; текст внутренних ошибок наружу не отдаётся
+func writeError(w http.ResponseWriter, err error) {
+	status := statusFor(err)
+
+	msg := err.Error()
+	if status == http.StatusInternalServerError {
+		log.Printf("❌ %v\n", err)
+		msg = http.StatusText(status)
+	}
+
+	resp := errorResponse{Error: msg}
+
+	var limitErr *service.LimitError
+	if errors.As(err, &limitErr) {
+		resp.Limit = &limitViolation{
+			Rule:      limitErr.Rule,
+			Value:     limitErr.Value,
+			Threshold: limitErr.Threshold,
+			Unit:      limitErr.Unit,
+		}
+	}
+
+	writeJSON(w, status, resp)
+}
@@ -0,0 +1,76 @@
+package app
+
+import (
+	"database/sql"
+	"fuelStation/internal/adapter/repository"
+	"fuelStation/internal/domain/entity"
+	"fuelStation/internal/domain/interfaces"
+	"fuelStation/internal/domain/service"
+	"fuelStation/internal/usecase"
+	"time"
+)
+
+// Собранное приложение: репозитории, сервисы и UseCase поверх одного подключения
+type App struct {
+	UseCase *usecase.UseCase
+
+	RefuelService   *service.RefuelOperationService
+	PriceService    *service.FuelPriceService
+	GradeService    *service.FuelGradeService
+	ScheduleService *service.PriceScheduleService
+	CounterService  *service.CounterStateService
+	DeviceService   *service.DeviceService
+	RefuelSweeper   *service.RefuelSweeper
+	RefundService   *service.RefundService
+	PaymentService  *service.PaymentService
+	ShiftService    *service.ShiftService
+
+	LogRepo interfaces.LogRepository
+}
+
+// pendingTTL и sweepInterval — настройки автоотмены зависших операций
+func New(db *sql.DB, defaultLimits entity.Limits, pendingTTL, sweepInterval time.Duration) *App {
+	// Репозитории
+	priceRepo := repository.NewFuelPriceRepository(db)
+	gradeRepo := repository.NewFuelGradeRepository(db)
+	scheduleRepo := repository.NewScheduledPriceRepository(db)
+	counterRepo := repository.NewCounterRepository(db)
+	deviceRepo := repository.NewDeviceRepository(db)
+	refuelRepo := repository.NewRefuelOperationRepository(db)
+	refundRepo := repository.NewRefundRepository(db)
+	paymentRepo := repository.NewPaymentRepository(db)
+	shiftRepo := repository.NewShiftRepository(db)
+	logRepo := repository.NewLogRepository(db)
+	limitsRepo := repository.NewLimitsRepository(db)
+	transactor := repository.NewTransactor(db)
+
+	// Сервисы
+	limits := service.NewLimitsPolicy(limitsRepo, defaultLimits)
+	gradeService := service.NewFuelGradeService(gradeRepo)
+	priceService := service.NewFuelPriceService(priceRepo, gradeRepo, limits)
+	scheduleService := service.NewPriceScheduleService(scheduleRepo, priceService, transactor, limits)
+	counterService := service.NewCounterStateService(counterRepo, deviceRepo)
+	deviceService := service.NewDeviceService(deviceRepo)
+	refuelService := service.NewRefuelOperationService(refuelRepo, paymentRepo, refundRepo, logRepo, priceService, counterService, deviceService, transactor, limits)
+	refuelSweeper := service.NewRefuelSweeper(refuelService, pendingTTL, sweepInterval)
+	refundService := service.NewRefundService(refundRepo, refuelRepo)
+	paymentService := service.NewPaymentService(paymentRepo, refuelRepo)
+	shiftService := service.NewShiftService(shiftRepo, paymentRepo, refundRepo, refuelService, counterService, deviceService)
+
+	return &App{
+		UseCase: usecase.NewUsecase(refuelService, refuelRepo, priceService, priceRepo, gradeService, scheduleService, counterService, counterRepo, deviceService, refuelSweeper, refundService, paymentService, shiftService, limits),
+
+		RefuelService:   refuelService,
+		PriceService:    priceService,
+		GradeService:    gradeService,
+		ScheduleService: scheduleService,
+		CounterService:  counterService,
+		DeviceService:   deviceService,
+		RefuelSweeper:   refuelSweeper,
+		RefundService:   refundService,
+		PaymentService:  paymentService,
+		ShiftService:    shiftService,
+
+		LogRepo: logRepo,
+	}
+}
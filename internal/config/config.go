@@ -0,0 +1,282 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"fuelStation/internal/adapter/repository"
+	"fuelStation/internal/domain/entity"
+	"fuelStation/internal/domain/service"
+	"io/fs"
+	"os"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Файл конфигурации по умолчанию (если существует)
+const DefaultPath = "config.yaml"
+
+// Переменная окружения с путём к файлу конфигурации
+const PathEnv = "FUELSTATION_CONFIG"
+
+type Config struct {
+	Database   DatabaseConfig   `yaml:"database"`
+	Migrations MigrationsConfig `yaml:"migrations"`
+	Limits     LimitsConfig     `yaml:"limits"`
+	Prices     PricesConfig     `yaml:"prices"`
+	Refuels    RefuelsConfig    `yaml:"refuels"`
+	Server     ServerConfig     `yaml:"server"`
+	Logs       LogsConfig       `yaml:"logs"`
+}
+
+type DatabaseConfig struct {
+	Host         string `yaml:"host"`
+	Port         int    `yaml:"port"`
+	User         string `yaml:"user"`
+	Password     string `yaml:"password"`
+	PasswordFile string `yaml:"password_file"` // пароль из файла (например, docker secret)
+	DBName       string `yaml:"dbname"`
+	SSLMode      string `yaml:"sslmode"`
+
+	MaxOpenConns    int           `yaml:"max_open_conns"`
+	MaxIdleConns    int           `yaml:"max_idle_conns"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime"`
+}
+
+type MigrationsConfig struct {
+	Dir string `yaml:"dir"` // пусто — миграции, вшитые в бинарник
+}
+
+// Лимиты по умолчанию, пока администратор не задал свои. Суммы в рублях: "10000" или "10000.50"
+type LimitsConfig struct {
+	MinPricePerLiter      Rubles `yaml:"min_price_per_liter"`
+	MaxPricePerLiter      Rubles `yaml:"max_price_per_liter"`
+	MinAmountPaid         Rubles `yaml:"min_amount_paid"`
+	MaxAmountPaid         Rubles `yaml:"max_amount_paid"`
+	MaxPriceChangePercent int64  `yaml:"max_price_change_percent"` // 0 — без ограничения
+}
+
+type PricesConfig struct {
+	ScheduleInterval time.Duration `yaml:"schedule_interval"` // как часто применять запланированные цены
+}
+
+type RefuelsConfig struct {
+	PendingTTL    time.Duration `yaml:"pending_ttl"`    // сколько операция может ждать подтверждения
+	SweepInterval time.Duration `yaml:"sweep_interval"` // как часто отменять зависшие операции
+}
+
+type ServerConfig struct {
+	HTTPAddr   string `yaml:"http_addr"`
+	GRPCAddr   string `yaml:"grpc_addr"`
+	AdminToken string `yaml:"admin_token"` // токен для административных запросов API; пусто — они запрещены
+}
+
+type LogsConfig struct {
+	KeepDays          int           `yaml:"keep_days"`
+	RetentionInterval time.Duration `yaml:"retention_interval"`
+}
+
+// Сумма в рублях в конфиге
+type Rubles entity.Money
+
+func (r *Rubles) UnmarshalText(text []byte) error {
+	m, err := entity.ParseMoney(string(text))
+	if err != nil {
+		return err
+	}
+	*r = Rubles(m)
+	return nil
+}
+
+// Значения по умолчанию
+func Default() Config {
+	limits := service.DefaultLimits()
+
+	return Config{
+		Database: DatabaseConfig{
+			Host:            "localhost",
+			Port:            5432,
+			User:            "postgres",
+			DBName:          "gasStation",
+			SSLMode:         "disable",
+			MaxOpenConns:    25,
+			MaxIdleConns:    5,
+			ConnMaxLifetime: 5 * time.Minute,
+		},
+		Limits: LimitsConfig{
+			MinPricePerLiter:      Rubles(limits.MinPricePerLiter),
+			MaxPricePerLiter:      Rubles(limits.MaxPricePerLiter),
+			MinAmountPaid:         Rubles(limits.MinAmountPaid),
+			MaxAmountPaid:         Rubles(limits.MaxAmountPaid),
+			MaxPriceChangePercent: limits.MaxPriceChangePercent,
+		},
+		Prices: PricesConfig{
+			ScheduleInterval: 30 * time.Second,
+		},
+		Refuels: RefuelsConfig{
+			PendingTTL:    15 * time.Minute,
+			SweepInterval: time.Minute,
+		},
+		Server: ServerConfig{
+			HTTPAddr: ":8080",
+			GRPCAddr: ":9090",
+		},
+		Logs: LogsConfig{
+			KeepDays:          30,
+			RetentionInterval: 24 * time.Hour,
+		},
+	}
+}
+
+// Загружает конфигурацию: значения по умолчанию, затем файл, затем переменные окружения.
+// Пустой path — файл из FUELSTATION_CONFIG или config.yaml, если он есть
+func Load(path string) (Config, error) {
+	cfg := Default()
+
+	explicit := path != ""
+	if !explicit {
+		path = os.Getenv(PathEnv)
+		explicit = path != ""
+	}
+	if path == "" {
+		path = DefaultPath
+	}
+
+	if err := cfg.loadFile(path); err != nil {
+		if explicit || !errors.Is(err, fs.ErrNotExist) {
+			return Config{}, err
+		}
+	}
+
+	// Файл пароля из конфига читается до окружения, чтобы FUELSTATION_DB_PASSWORD его перекрывал
+	if err := cfg.readPasswordFile(); err != nil {
+		return Config{}, err
+	}
+
+	if err := cfg.applyEnv(); err != nil {
+		return Config{}, err
+	}
+
+	// FUELSTATION_DB_PASSWORD_FILE учитывается, только если пароль не задан самой переменной
+	if _, ok := os.LookupEnv("FUELSTATION_DB_PASSWORD"); !ok {
+		if err := cfg.readPasswordFile(); err != nil {
+			return Config{}, err
+		}
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return Config{}, err
+	}
+
+	return cfg, nil
+}
+
+func (c *Config) loadFile(path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("ошибка чтения конфигурации %s: %w", path, err)
+	}
+
+	dec := yaml.NewDecoder(strings.NewReader(string(content)))
+	dec.KnownFields(true)
+	if err := dec.Decode(c); err != nil {
+		return fmt.Errorf("ошибка разбора конфигурации %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// Подставляет пароль из database.password_file, если он задан
+func (c *Config) readPasswordFile() error {
+	if c.Database.PasswordFile == "" {
+		return nil
+	}
+
+	content, err := os.ReadFile(c.Database.PasswordFile)
+	if err != nil {
+		return fmt.Errorf("ошибка чтения файла пароля: %w", err)
+	}
+	c.Database.Password = strings.TrimRight(string(content), "\r\n")
+	c.Database.PasswordFile = ""
+
+	return nil
+}
+
+// Проверка значений при старте
+func (c Config) Validate() error {
+	var errs []error
+	check := func(ok bool, msg string) {
+		if !ok {
+			errs = append(errs, errors.New(msg))
+		}
+	}
+
+	db := c.Database
+	check(db.Host != "", "database.host не задан")
+	check(db.Port > 0 && db.Port < 65536, "database.port должен быть в диапазоне 1-65535")
+	check(db.User != "", "database.user не задан")
+	check(db.DBName != "", "database.dbname не задан")
+	check(db.MaxOpenConns > 0, "database.max_open_conns должен быть больше 0")
+	check(db.MaxIdleConns >= 0 && db.MaxIdleConns <= db.MaxOpenConns, "database.max_idle_conns должен быть от 0 до max_open_conns")
+	check(db.ConnMaxLifetime >= 0, "database.conn_max_lifetime не может быть отрицательным")
+
+	check(c.Limits.MaxPricePerLiter > 0, "limits.max_price_per_liter должен быть больше 0")
+	check(c.Limits.MinPricePerLiter >= 0 && c.Limits.MinPricePerLiter <= c.Limits.MaxPricePerLiter,
+		"limits.min_price_per_liter должен быть от 0 до max_price_per_liter")
+	check(c.Limits.MaxAmountPaid > 0, "limits.max_amount_paid должен быть больше 0")
+	check(c.Limits.MinAmountPaid >= 0 && c.Limits.MinAmountPaid <= c.Limits.MaxAmountPaid,
+		"limits.min_amount_paid должен быть от 0 до max_amount_paid")
+	check(c.Limits.MaxPriceChangePercent >= 0, "limits.max_price_change_percent не может быть отрицательным")
+
+	check(c.Prices.ScheduleInterval > 0, "prices.schedule_interval должен быть больше 0")
+
+	check(c.Refuels.PendingTTL > 0, "refuels.pending_ttl должен быть больше 0")
+	check(c.Refuels.SweepInterval > 0, "refuels.sweep_interval должен быть больше 0")
+
+	check(c.Server.HTTPAddr != "", "server.http_addr не задан")
+	check(c.Server.GRPCAddr != "", "server.grpc_addr не задан")
+	check(c.Server.AdminToken == strings.TrimSpace(c.Server.AdminToken), "server.admin_token не должен начинаться или заканчиваться пробелами")
+
+	check(c.Logs.KeepDays > 0, "logs.keep_days должен быть больше 0")
+	check(c.Logs.RetentionInterval > 0, "logs.retention_interval должен быть больше 0")
+
+	if len(errs) > 0 {
+		return fmt.Errorf("некорректная конфигурация: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// Настройки подключения для репозитория
+func (c Config) RepositoryConfig() repository.DatabaseConfig {
+	return repository.DatabaseConfig{
+		Host:            c.Database.Host,
+		Port:            c.Database.Port,
+		User:            c.Database.User,
+		Password:        c.Database.Password,
+		DBName:          c.Database.DBName,
+		SSLMode:         c.Database.SSLMode,
+		MaxOpenConns:    c.Database.MaxOpenConns,
+		MaxIdleConns:    c.Database.MaxIdleConns,
+		ConnMaxLifetime: c.Database.ConnMaxLifetime,
+	}
+}
+
+// Источник миграций: каталог из конфига или вшитые
+func (c Config) MigrationsFS() fs.FS {
+	if c.Migrations.Dir != "" {
+		return repository.DirMigrations(c.Migrations.Dir)
+	}
+	return repository.EmbeddedMigrations()
+}
+
+// Лимиты по умолчанию для сервисов
+func (c Config) ServiceLimits() entity.Limits {
+	return entity.Limits{
+		MinPricePerLiter:      entity.Money(c.Limits.MinPricePerLiter),
+		MaxPricePerLiter:      entity.Money(c.Limits.MaxPricePerLiter),
+		MinAmountPaid:         entity.Money(c.Limits.MinAmountPaid),
+		MaxAmountPaid:         entity.Money(c.Limits.MaxAmountPaid),
+		MaxPriceChangePercent: c.Limits.MaxPriceChangePercent,
+	}
+}
@@ -0,0 +1,103 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Переопределение значений из переменных окружения FUELSTATION_*
+func (c *Config) applyEnv() error {
+	vars := []struct {
+		name string
+		set  func(string) error
+	}{
+		{"FUELSTATION_DB_HOST", setString(&c.Database.Host)},
+		{"FUELSTATION_DB_PORT", setInt(&c.Database.Port)},
+		{"FUELSTATION_DB_USER", setString(&c.Database.User)},
+		{"FUELSTATION_DB_PASSWORD", setString(&c.Database.Password)},
+		{"FUELSTATION_DB_PASSWORD_FILE", setString(&c.Database.PasswordFile)},
+		{"FUELSTATION_DB_NAME", setString(&c.Database.DBName)},
+		{"FUELSTATION_DB_SSLMODE", setString(&c.Database.SSLMode)},
+		{"FUELSTATION_DB_MAX_OPEN_CONNS", setInt(&c.Database.MaxOpenConns)},
+		{"FUELSTATION_DB_MAX_IDLE_CONNS", setInt(&c.Database.MaxIdleConns)},
+		{"FUELSTATION_DB_CONN_MAX_LIFETIME", setDuration(&c.Database.ConnMaxLifetime)},
+
+		{"FUELSTATION_MIGRATIONS_DIR", setString(&c.Migrations.Dir)},
+
+		{"FUELSTATION_LIMIT_MIN_PRICE_PER_LITER", c.Limits.MinPricePerLiter.set},
+		{"FUELSTATION_LIMIT_MAX_PRICE_PER_LITER", c.Limits.MaxPricePerLiter.set},
+		{"FUELSTATION_LIMIT_MIN_AMOUNT_PAID", c.Limits.MinAmountPaid.set},
+		{"FUELSTATION_LIMIT_MAX_AMOUNT_PAID", c.Limits.MaxAmountPaid.set},
+		{"FUELSTATION_LIMIT_MAX_PRICE_CHANGE_PERCENT", setInt64(&c.Limits.MaxPriceChangePercent)},
+
+		{"FUELSTATION_PRICE_SCHEDULE_INTERVAL", setDuration(&c.Prices.ScheduleInterval)},
+
+		{"FUELSTATION_REFUEL_PENDING_TTL", setDuration(&c.Refuels.PendingTTL)},
+		{"FUELSTATION_REFUEL_SWEEP_INTERVAL", setDuration(&c.Refuels.SweepInterval)},
+
+		{"FUELSTATION_HTTP_ADDR", setString(&c.Server.HTTPAddr)},
+		{"FUELSTATION_GRPC_ADDR", setString(&c.Server.GRPCAddr)},
+		{"FUELSTATION_ADMIN_TOKEN", setString(&c.Server.AdminToken)},
+
+		{"FUELSTATION_LOG_KEEP_DAYS", setInt(&c.Logs.KeepDays)},
+		{"FUELSTATION_LOG_RETENTION_INTERVAL", setDuration(&c.Logs.RetentionInterval)},
+	}
+
+	for _, v := range vars {
+		value, ok := os.LookupEnv(v.name)
+		if !ok {
+			continue
+		}
+		if err := v.set(value); err != nil {
+			return fmt.Errorf("некорректное значение %s: %w", v.name, err)
+		}
+	}
+
+	return nil
+}
+
+func (r *Rubles) set(v string) error {
+	return r.UnmarshalText([]byte(v))
+}
+
+func setString(dst *string) func(string) error {
+	return func(v string) error {
+		*dst = v
+		return nil
+	}
+}
+
+func setInt(dst *int) func(string) error {
+	return func(v string) error {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return err
+		}
+		*dst = n
+		return nil
+	}
+}
+
+func setInt64(dst *int64) func(string) error {
+	return func(v string) error {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return err
+		}
+		*dst = n
+		return nil
+	}
+}
+
+func setDuration(dst *time.Duration) func(string) error {
+	return func(v string) error {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return err
+		}
+		*dst = d
+		return nil
+	}
+}
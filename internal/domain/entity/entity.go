@@ -4,32 +4,119 @@ import (
 	"time"
 )
 
+// Марка топлива (АИ-92, АИ-95, ДТ)
+type FuelGrade struct {
+	Code      string    `json:"code"` // код марки, например AI95
+	Name      string    `json:"name"` // название для людей, например АИ-95
+	CreatedAt time.Time `json:"created_at"`
+}
+
 // Текущая цена топлива
 type FuelPrice struct {
 	ID            int64     `json:"id"`
-	PricePerLiter float64   `json:"price_per_liter"`
+	GradeCode     string    `json:"grade_code"`      // марка топлива
+	PricePerLiter Money     `json:"price_per_liter"` // копейки за литр
 	CreatedAt     time.Time `json:"created_at"`
 	IsActive      bool      `json:"is_active"`
 }
 
+// Цена с периодом, когда она действовала: [From, To). To == nil — действует сейчас
+type PriceInterval struct {
+	Price FuelPrice
+	From  time.Time
+	To    *time.Time
+}
+
+// Запланированная смена цены марки
+type ScheduledPrice struct {
+	ID            int64      `json:"id"`
+	GradeCode     string     `json:"grade_code"`      // марка топлива
+	PricePerLiter Money      `json:"price_per_liter"` // новая цена, копейки за литр
+	EffectiveFrom time.Time  `json:"effective_from"`  // не раньше какого момента применить
+	Status        string     `json:"status"`          // Pending, Applied, Cancelled, Failed
+	CreatedAt     time.Time  `json:"created_at"`
+	AppliedAt     *time.Time `json:"applied_at,omitempty"`     // когда цена реально стала активной
+	CancelledAt   *time.Time `json:"cancelled_at,omitempty"`   // когда отменена
+	FailedAt      *time.Time `json:"failed_at,omitempty"`      // когда применить не удалось
+	FailureReason *string    `json:"failure_reason,omitempty"` // почему не удалось применить
+}
+
+// Колонка (устройство) станции со своим счётчиком
+type Device struct {
+	ID        string    `json:"id"`        // идентификатор, например pump-1
+	Name      string    `json:"name"`      // название для людей
+	IsActive  bool      `json:"is_active"` // выключенная колонка не принимает новые заправки
+	CreatedAt time.Time `json:"created_at"`
+}
+
 // Текущее состояние счётчика колонки/фургона
 type CounterState struct {
 	Id           int64     // уникальный идентификатор операции
-	CurrentValue int64       // текущее показание счётчика в литрах
+	DeviceID     string    // колонка, которой принадлежит счётчик
+	CurrentValue int64     // текущее показание счётчика в литрах
 	UpdatedAt    time.Time // время последнего обновления
+	Version      int64     // версия записи, защищает от одновременной перезаписи
 }
 
 // Операция заправки
 type RefuelOperation struct {
 	ID               int64      // уникальный идентификатор операции
-	AmountPaid       float64    // сумма денег, внесённая клиентом
-	CalculatedLiters float64    // литры, рассчитанные по цене
-	PricePerLiter    float64    // цена за литр на момент операции (копия)
-	CounterBefore    int64        // показание счётчика до заправки
-	CounterAfter     int64        // показание счётчика после заправки
-	Status           string     // статус операции: Created, Confirmed, Cancelled и т.п.
+	DeviceID         string     // колонка, на которой идёт заправка
+	GradeCode        string     // марка топлива
+	Mode             string     // как задан объём: Amount, Volume, FillUp
+	AmountPaid       Money      // сумма денег, внесённая клиентом (копейки); для FillUp — после подтверждения
+	Paid             Money      // сколько из AmountPaid разнесено по платежам
+	CalculatedLiters Volume     // объём, рассчитанный по цене (десятые литра)
+	DispensedLiters  *Volume    // фактически отпущенный объём по счётчику (если подтверждено по показанию)
+	RefundDue        Money      // сколько нужно вернуть клиенту: за недолитое топливо или всё при отмене
+	Refunded         Money      // сколько из RefundDue уже выдано клиенту
+	PricePerLiter    Money      // цена за литр на момент операции (копия, копейки)
+	CounterBefore    int64      // показание счётчика до заправки
+	CounterAfter     int64      // показание счётчика после заправки
+	Status           string     // статус операции: Created, Confirmed, PartiallyFulfilled, Cancelled
 	CreatedAt        time.Time  // дата и время создания операции
+	ConfirmedAt      *time.Time // дата и время подтверждения (опционально)
 	CancelledAt      *time.Time // дата и время отмены (опционально)
+	CancelReason     *string    // причина отмены (опционально)
+	IdempotencyKey   *string    // ключ идемпотентности от терминала (опционально)
+}
+
+// Платёж по операции; одну операцию можно оплатить несколькими способами
+type Payment struct {
+	ID          int64     // уникальный идентификатор платежа
+	OperationID int64     // оплаченная операция
+	Method      string    // способ: Cash, Card, SBP, FuelCard
+	Amount      Money     // сумма платежа (копейки)
+	ExternalRef *string   // номер транзакции эквайринга, СБП или топливной карты (опционально)
+	CreatedAt   time.Time // когда принят
+}
+
+// Смена оператора: все операции между открытием и закрытием относятся к ней
+type Shift struct {
+	ID          int64          // уникальный идентификатор смены
+	Operator    string         // кто работал
+	OpenedAt    time.Time      // открытие смены
+	ClosedAt    *time.Time     // закрытие смены (nil — смена открыта)
+	OpeningCash Money          // наличные в кассе на открытии (копейки)
+	ClosingCash *Money         // наличные в кассе на закрытии
+	Counters    []ShiftCounter // показания счётчиков колонок
+}
+
+// Показания счётчика колонки на открытии и закрытии смены
+type ShiftCounter struct {
+	DeviceID   string
+	StartValue int64  // на открытии
+	EndValue   *int64 // на закрытии (nil, пока смена открыта)
+}
+
+// Возврат денег клиенту по операции (например, за недолитое топливо)
+type Refund struct {
+	ID          int64     // уникальный идентификатор возврата
+	OperationID int64     // операция, по которой выдан возврат
+	Amount      Money     // сумма возврата (копейки)
+	Method      string    // способ: Cash, Card
+	IssuedBy    string    // кто выдал (оператор)
+	IssuedAt    time.Time // когда выдан
 }
 
 // Логи для просмотра в приложении
@@ -42,3 +129,13 @@ type LogRecord struct {
 	Meta      string    // дополнительные данные в JSON (может быть пустым)
 	CreatedAt time.Time // время события
 }
+
+// Лимиты проверки цены и оплаты (0 в Min*/MaxPriceChangePercent — без ограничения)
+type Limits struct {
+	MinPricePerLiter      Money     // минимальная цена за литр
+	MaxPricePerLiter      Money     // максимальная цена за литр
+	MinAmountPaid         Money     // минимальная сумма одной оплаты
+	MaxAmountPaid         Money     // максимальная сумма одной оплаты
+	MaxPriceChangePercent int64     // максимальное изменение цены за раз, в процентах
+	UpdatedAt             time.Time // время последнего изменения
+}
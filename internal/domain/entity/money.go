@@ -0,0 +1,136 @@
+package entity
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Деньги в копейках
+type Money int64
+
+// Объём топлива в десятых долях литра — та же единица, что и у счётчика колонки
+type Volume int64
+
+const (
+	KopecksPerRuble     = 100
+	VolumeUnitsPerLiter = 10
+)
+
+// Рубли -> копейки, с округлением до ближайшей копейки (половина от нуля)
+func MoneyFromRubles(rub float64) Money {
+	return Money(math.Round(rub * KopecksPerRuble))
+}
+
+// Значение в рублях (только для вывода, считать нужно в копейках)
+func (m Money) Rubles() float64 {
+	return float64(m) / KopecksPerRuble
+}
+
+// Формат "1234.56"
+func (m Money) String() string {
+	sign := ""
+	v := int64(m)
+	if v < 0 {
+		sign, v = "-", -v
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, v/KopecksPerRuble, v%KopecksPerRuble)
+}
+
+// Литры -> десятые доли литра, с округлением до ближайшей десятой
+func VolumeFromLiters(liters float64) Volume {
+	return Volume(math.Round(liters * VolumeUnitsPerLiter))
+}
+
+// Значение в литрах (только для вывода)
+func (v Volume) Liters() float64 {
+	return float64(v) / VolumeUnitsPerLiter
+}
+
+// Формат "12.3"
+func (v Volume) String() string {
+	sign := ""
+	n := int64(v)
+	if n < 0 {
+		sign, n = "-", -n
+	}
+	return fmt.Sprintf("%s%d.%d", sign, n/VolumeUnitsPerLiter, n%VolumeUnitsPerLiter)
+}
+
+// Сколько топлива можно отпустить на сумму amount по цене pricePerLiter.
+// Округляется вниз до десятой литра: клиент не получает больше, чем оплатил
+func VolumeFor(amount, pricePerLiter Money) Volume {
+	if pricePerLiter <= 0 {
+		return 0
+	}
+	return Volume(int64(amount) * VolumeUnitsPerLiter / int64(pricePerLiter))
+}
+
+// Стоимость объёма volume по цене pricePerLiter, с округлением до ближайшей копейки
+func CostOf(volume Volume, pricePerLiter Money) Money {
+	return Money(DivRound(int64(volume)*int64(pricePerLiter), VolumeUnitsPerLiter))
+}
+
+// Целочисленное деление с округлением половины от нуля (для средних и стоимостей)
+func DivRound(a, b int64) int64 {
+	if b == 0 {
+		return 0
+	}
+	if (a < 0) != (b < 0) {
+		return (a - b/2) / b
+	}
+	return (a + b/2) / b
+}
+
+// Разбирает сумму в рублях вида "1234", "-1234.5" или "1234,56" без потери точности.
+// Знак допускается только первым символом
+func ParseMoney(s string) (Money, error) {
+	input := s
+	s = strings.TrimSpace(strings.Replace(s, ",", ".", 1))
+
+	neg := strings.HasPrefix(s, "-")
+	if neg || strings.HasPrefix(s, "+") {
+		s = s[1:]
+	}
+
+	rub, kop, hasKop := strings.Cut(s, ".")
+	if !isDigits(rub) || (hasKop && (!isDigits(kop) || len(kop) > 2)) {
+		return 0, fmt.Errorf("некорректная сумма %q", input)
+	}
+	if len(kop) == 1 {
+		kop += "0"
+	}
+
+	r, err := strconv.ParseInt(rub, 10, 64)
+	if err != nil || r > math.MaxInt64/KopecksPerRuble-1 {
+		return 0, fmt.Errorf("некорректная сумма %q", input)
+	}
+
+	var k int64
+	if hasKop {
+		k, err = strconv.ParseInt(kop, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("некорректная сумма %q", input)
+		}
+	}
+
+	m := Money(r*KopecksPerRuble + k)
+	if neg {
+		m = -m
+	}
+	return m, nil
+}
+
+// Непустая строка только из цифр 0-9
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
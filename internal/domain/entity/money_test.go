@@ -0,0 +1,198 @@
+package entity
+
+import "testing"
+
+func TestMoneyFromRubles(t *testing.T) {
+	tests := []struct {
+		rub  float64
+		want Money
+	}{
+		{0, 0},
+		{1, 100},
+		{12.34, 1234},
+		{1.125, 113}, // 112.5 коп. — половина от нуля
+		{0.375, 38},
+		{-1.125, -113},
+		{-0.375, -38},
+	}
+	for _, tt := range tests {
+		if got := MoneyFromRubles(tt.rub); got != tt.want {
+			t.Errorf("MoneyFromRubles(%v) = %d, want %d", tt.rub, got, tt.want)
+		}
+	}
+}
+
+func TestDivRound(t *testing.T) {
+	tests := []struct {
+		a, b, want int64
+	}{
+		{10, 5, 2},
+		{5, 2, 3},
+		{7, 2, 4},
+		{4, 3, 1},
+		{5, 3, 2},
+		{-5, 2, -3},
+		{-7, 2, -4},
+		{5, -2, -3},
+		{-5, -2, 3},
+		{-4, 3, -1},
+		{1, 0, 0},
+	}
+	for _, tt := range tests {
+		if got := DivRound(tt.a, tt.b); got != tt.want {
+			t.Errorf("DivRound(%d, %d) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestMoneyString(t *testing.T) {
+	tests := []struct {
+		m    Money
+		want string
+	}{
+		{0, "0.00"},
+		{5, "0.05"},
+		{123456, "1234.56"},
+		{-5, "-0.05"},
+		{-123456, "-1234.56"},
+	}
+	for _, tt := range tests {
+		if got := tt.m.String(); got != tt.want {
+			t.Errorf("Money(%d).String() = %q, want %q", tt.m, got, tt.want)
+		}
+	}
+}
+
+func TestVolumeString(t *testing.T) {
+	tests := []struct {
+		v    Volume
+		want string
+	}{
+		{0, "0.0"},
+		{7, "0.7"},
+		{123, "12.3"},
+		{-7, "-0.7"},
+	}
+	for _, tt := range tests {
+		if got := tt.v.String(); got != tt.want {
+			t.Errorf("Volume(%d).String() = %q, want %q", tt.v, got, tt.want)
+		}
+	}
+}
+
+func TestVolumeFor(t *testing.T) {
+	tests := []struct {
+		amount, price Money
+		want          Volume
+	}{
+		{100000, 5000, 200},
+		{100000, 5499, 181}, // 18.18... л
+		{5499, 5499, 10},
+		{5498, 5499, 9},
+		{549, 5499, 0},
+		{550, 5499, 1},
+		{100000, 0, 0},
+		{100000, -1, 0},
+	}
+	for _, tt := range tests {
+		if got := VolumeFor(tt.amount, tt.price); got != tt.want {
+			t.Errorf("VolumeFor(%d, %d) = %d, want %d", tt.amount, tt.price, got, tt.want)
+		}
+	}
+}
+
+func TestCostOf(t *testing.T) {
+	tests := []struct {
+		volume Volume
+		price  Money
+		want   Money
+	}{
+		{200, 5000, 100000},
+		{1, 5499, 550}, // 549.9
+		{1, 5495, 550}, // 549.5 — половина вверх
+		{1, 5494, 549}, // 549.4
+		{181, 5499, 99532},
+		{0, 5499, 0},
+	}
+	for _, tt := range tests {
+		if got := CostOf(tt.volume, tt.price); got != tt.want {
+			t.Errorf("CostOf(%d, %d) = %d, want %d", tt.volume, tt.price, got, tt.want)
+		}
+	}
+}
+
+// Отпущенное на сумму стоит не больше суммы и меньше её не более чем на десятую литра
+func TestVolumeForCostOfRoundTrip(t *testing.T) {
+	for _, price := range []Money{4550, 4999, 5499, 5863, 6720, 7001} {
+		for amount := Money(100); amount <= 1000000; amount += 1237 {
+			volume := VolumeFor(amount, price)
+			cost := CostOf(volume, price)
+			if cost > amount {
+				t.Fatalf("price %d, amount %d: cost %d of volume %d exceeds amount", price, amount, cost, volume)
+			}
+			if amount-cost >= CostOf(1, price)+1 {
+				t.Fatalf("price %d, amount %d: volume %d leaves %d unspent", price, amount, volume, amount-cost)
+			}
+		}
+	}
+}
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Money
+	}{
+		{"0", 0},
+		{"1234", 123400},
+		{"1234.5", 123450},
+		{"1234.56", 123456},
+		{"1234,56", 123456},
+		{"0.05", 5},
+		{" 12.30 ", 1230},
+		{"-5", -500},
+		{"-0.05", -5},
+		{"+5", 500},
+		{"007", 700},
+	}
+	for _, tt := range tests {
+		got, err := ParseMoney(tt.in)
+		if err != nil {
+			t.Errorf("ParseMoney(%q) error: %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseMoney(%q) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseMoneyInvalid(t *testing.T) {
+	for _, in := range []string{
+		"",
+		"-",
+		"+",
+		".",
+		".5",
+		"5.",
+		"1.234",
+		"--5",
+		"+-5",
+		"-+5",
+		"1.+5",
+		"1.-5",
+		"1.5-",
+		"5-",
+		"1 000",
+		"1,000.00",
+		"1.2.3",
+		"abc",
+		"12a",
+		"0x10",
+		"1e3",
+		"99999999999999999999",
+	} {
+		if got, err := ParseMoney(in); err == nil {
+			t.Errorf("ParseMoney(%q) = %d, want error", in, got)
+		}
+	}
+}
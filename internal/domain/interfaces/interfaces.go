@@ -6,11 +6,31 @@ import (
 	"time"
 )
 
+// Единица работы: все вызовы репозиториев с ctx, переданным в fn,
+// фиксируются вместе или откатываются при ошибке
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type FuelGradeRepository interface {
+	// Все марки топлива
+	List(ctx context.Context) ([]entity.FuelGrade, error)
+
+	// Марка по коду (ErrNotFoundGrade, если такой нет)
+	GetByCode(ctx context.Context, code string) (entity.FuelGrade, error)
+
+	// Добавить марку (ErrGradeAlreadyExists, если код занят)
+	Create(ctx context.Context, grade *entity.FuelGrade) error
+}
+
 type FuelPriceRepository interface {
-	// Получить текущую активную цену
-	GetActive(ctx context.Context) (entity.FuelPrice, error)
+	// Получить текущую активную цену марки
+	GetActive(ctx context.Context, grade string) (entity.FuelPrice, error)
+
+	// Активные цены всех марок
+	ListActive(ctx context.Context) ([]entity.FuelPrice, error)
 
-	// Создать/изменить цену (деактивирует предыдущую)
+	// Создать/изменить цену марки price.GradeCode (деактивирует предыдущую)
 	ChangePrice(ctx context.Context, price entity.FuelPrice) error
 
 	// Универсальный поиск цен
@@ -19,11 +39,50 @@ type FuelPriceRepository interface {
 	// По ID (для редких случаев)
 	GetByID(ctx context.Context, id string) (entity.FuelPrice, error)
 
-	// Деактивировать все активные цены и активировать новую
+	// Деактивировать активную цену марки price.GradeCode и активировать новую
 	ActivateNewPrice(ctx context.Context, price entity.FuelPrice) error
+
+	// Цена марки, действовавшая в момент t (ErrNotFoundPrice, если цены ещё не было)
+	GetAt(ctx context.Context, grade string, t time.Time) (entity.FuelPrice, error)
+
+	// Цены с периодами действия, пересекающимися с [filter.DateFrom, filter.DateTo]
+	FindIntervals(ctx context.Context, filter FuelPriceFilter) ([]entity.PriceInterval, error)
+}
+
+type ScheduledPriceRepository interface {
+	// Запланировать цену (проставляет ID)
+	Create(ctx context.Context, price *entity.ScheduledPrice) error
+
+	// По ID (ErrNotFoundScheduledPrice, если нет)
+	GetByID(ctx context.Context, id string) (entity.ScheduledPrice, error)
+
+	// Поиск запланированных цен (по возрастанию EffectiveFrom)
+	Find(ctx context.Context, filter ScheduledPriceFilter) ([]entity.ScheduledPrice, error)
+
+	// Отменить ещё не применённую цену (ErrScheduledPriceNotPending, если уже применена или отменена)
+	Cancel(ctx context.Context, id string) error
+
+	// Взять самую раннюю наступившую цену и заблокировать её до конца транзакции;
+	// другие экземпляры её пропускают. ErrNotFoundScheduledPrice, если таких нет.
+	// Вызывается только внутри Transactor.WithinTransaction
+	LockNextDue(ctx context.Context, now time.Time) (entity.ScheduledPrice, error)
+
+	// Отметить цену применённой
+	MarkApplied(ctx context.Context, id int64, appliedAt time.Time) error
+
+	// Отметить, что цену не удалось применить (ErrScheduledPriceNotPending, если она уже не ожидает)
+	MarkFailed(ctx context.Context, id int64, failedAt time.Time, reason string) error
+}
+
+type ScheduledPriceFilter struct {
+	Grade  *string
+	Status *string
+	Limit  *int
+	Offset *int
 }
 
 type FuelPriceFilter struct {
+	Grade    *string
 	IsActive *bool
 	DateFrom *time.Time
 	DateTo   *time.Time
@@ -31,32 +90,128 @@ type FuelPriceFilter struct {
 	Offset   *int
 }
 
+type DeviceRepository interface {
+	// Все колонки
+	List(ctx context.Context) ([]entity.Device, error)
+
+	// Колонка по ID (ErrNotFoundDevice, если такой нет)
+	GetByID(ctx context.Context, id string) (entity.Device, error)
+
+	// Зарегистрировать колонку (ErrDeviceAlreadyExists, если ID занят)
+	Create(ctx context.Context, device *entity.Device) error
+
+	// Включить/выключить колонку
+	SetActive(ctx context.Context, id string, active bool) error
+}
+
 type CounterRepository interface {
-	// Текущее состояние счётчика (всегда одна запись)
-	GetCurrent(ctx context.Context) (entity.CounterState, error)
+	// Текущее состояние счётчика колонки (одна запись на колонку)
+	GetCurrent(ctx context.Context, deviceID string) (entity.CounterState, error)
 
-	// Сохранить новое состояние
+	// Сохранить новое состояние счётчика колонки state.DeviceID (state.Version — версия,
+	// от которой делалось изменение, 0 для первой записи). При устаревшей версии возвращает ErrCounterVersionConflict
 	Save(ctx context.Context, state entity.CounterState) error
 }
 
 type RefuelOperationsRepo interface {
-	Create(ctx context.Context, operation entity.RefuelOperation) error
+	// Создать операцию (проставляет ID). Занятый ключ идемпотентности — ErrDuplicateIdempotencyKey
+	Create(ctx context.Context, operation *entity.RefuelOperation) error
 
 	// Получить по ID
 	GetByID(ctx context.Context, id string) (entity.RefuelOperation, error)
 
+	// Получить по ключу идемпотентности
+	GetByIdempotencyKey(ctx context.Context, key string) (entity.RefuelOperation, error)
+
 	// Универсальный поиск операций
 	Find(ctx context.Context, filter RefuelFilter) ([]entity.RefuelOperation, error)
 
-	// Обновить статус операции
-	UpdateStatus(ctx context.Context, id string, status string, reason *string) error
+	// Обновить статус операции, если её текущий статус — один из expected, иначе ErrInvalidOperationStatus
+	// (reason сохраняется как причина отмены)
+	UpdateStatus(ctx context.Context, id string, status string, expected []string, reason *string) error
+
+	// Отменить операцию, только если она всё ещё в Created и создана не позже before
+	// (иначе ErrInvalidOperationStatus); возвращает отменённую операцию
+	CancelExpired(ctx context.Context, id int64, before time.Time, reason string) (entity.RefuelOperation, error)
+
+	// Сохранить итог заправки по счётчику: статус, CounterAfter, DispensedLiters, RefundDue,
+	// а также AmountPaid и CalculatedLiters (для FillUp они известны только после заправки)
+	UpdateDispensed(ctx context.Context, operation entity.RefuelOperation) error
 }
 
 type RefuelFilter struct {
-	DeviceID *string
-	DateFrom *time.Time
-	DateTo   *time.Time
-	Status   *string
+	DeviceID          *string
+	Grade             *string
+	DateFrom          *time.Time
+	DateTo            *time.Time
+	ConfirmedFrom     *time.Time // по времени подтверждения
+	ConfirmedTo       *time.Time
+	CancelledFrom     *time.Time // по времени отмены
+	CancelledTo       *time.Time
+	Status            *string
+	Statuses          []string // любой из статусов
+	RefundOutstanding bool     // только операции с ещё не выданным возвратом
+	Limit             *int
+	Offset            *int
+}
+
+type RefundRepository interface {
+	// Сохранить возврат (проставляет ID) и учесть его в операции.
+	// Если вместе с выданными ранее сумма превышает RefundDue или возврат по операции
+	// в её статусе не положен — ErrRefundExceedsDue
+	Create(ctx context.Context, refund *entity.Refund) error
+
+	// Возвраты по операции
+	ListByOperation(ctx context.Context, operationID int64) ([]entity.Refund, error)
+
+	// Возвраты по нескольким операциям
+	ListByOperations(ctx context.Context, operationIDs []int64) ([]entity.Refund, error)
+
+	// Возвраты, выданные в промежутке [from, to)
+	ListByPeriod(ctx context.Context, from, to time.Time) ([]entity.Refund, error)
+}
+
+type PaymentRepository interface {
+	// Сохранить платёж (проставляет ID) и учесть его в операции. Если вместе с принятыми
+	// ранее сумма превышает AmountPaid или операция отменена — ErrPaymentExceedsAmount
+	Create(ctx context.Context, payment *entity.Payment) error
+
+	// Платежи по операции
+	ListByOperation(ctx context.Context, operationID int64) ([]entity.Payment, error)
+
+	// Платежи по нескольким операциям
+	ListByOperations(ctx context.Context, operationIDs []int64) ([]entity.Payment, error)
+
+	// Платежи, принятые в промежутке [from, to)
+	ListByPeriod(ctx context.Context, from, to time.Time) ([]entity.Payment, error)
+}
+
+type ShiftRepository interface {
+	// Открыть смену вместе с показаниями счётчиков (проставляет ID).
+	// Если уже есть открытая смена — ErrShiftAlreadyOpen
+	Create(ctx context.Context, shift *entity.Shift) error
+
+	// Открытая смена (ErrNoOpenShift, если все закрыты)
+	GetOpen(ctx context.Context) (entity.Shift, error)
+
+	// По ID (ErrNotFoundShift, если нет)
+	GetByID(ctx context.Context, id string) (entity.Shift, error)
+
+	// Закрыть смену: ClosedAt, ClosingCash, EndValue счётчиков и Z-отчёт report.
+	// ErrNoOpenShift, если смена уже закрыта
+	Close(ctx context.Context, shift entity.Shift, report []byte) error
+
+	// Z-отчёт, сохранённый при закрытии (nil, если его нет)
+	GetReport(ctx context.Context, id int64) ([]byte, error)
+
+	// Поиск смен (по убыванию OpenedAt), без счётчиков
+	Find(ctx context.Context, filter ShiftFilter) ([]entity.Shift, error)
+}
+
+type ShiftFilter struct {
+	Operator *string
+	DateFrom *time.Time // смены, открытые не раньше
+	DateTo   *time.Time // смены, открытые не позже
 	Limit    *int
 	Offset   *int
 }
@@ -81,3 +236,11 @@ type LogFilter struct {
 	Limit     *int
 	Offset    *int
 }
+
+type LimitsRepository interface {
+	// Текущие лимиты (ErrNotFoundLimits, если ещё не задавались)
+	Get(ctx context.Context) (entity.Limits, error)
+
+	// Сохранить лимиты
+	Save(ctx context.Context, limits entity.Limits) error
+}
@@ -9,28 +9,76 @@ import (
 )
 
 type FuelPriceService struct {
-	repo interfaces.FuelPriceRepository
+	repo   interfaces.FuelPriceRepository
+	grades interfaces.FuelGradeRepository
+	limits *LimitsPolicy
 }
 
-func NewFuelPriceService(repo interfaces.FuelPriceRepository) *FuelPriceService {
+func NewFuelPriceService(repo interfaces.FuelPriceRepository, grades interfaces.FuelGradeRepository, limits *LimitsPolicy) *FuelPriceService {
 	return &FuelPriceService{
-		repo: repo,
+		repo:   repo,
+		grades: grades,
+		limits: limits,
 	}
 }
 
-// Получить активную цену
-func (f *FuelPriceService) GetActive(ctx context.Context) (entity.FuelPrice, error) {
-	return f.repo.GetActive(ctx)
+// Получить активную цену марки
+func (f *FuelPriceService) GetActive(ctx context.Context, grade string) (entity.FuelPrice, error) {
+	grade = NormalizeGradeCode(grade)
+
+	price, err := f.repo.GetActive(ctx, grade)
+	if errors.Is(err, ErrNotFoundOldPrice) {
+		// Отличаем "цена не задана" от "нет такой марки"
+		if _, gradeErr := f.grades.GetByCode(ctx, grade); gradeErr != nil {
+			return entity.FuelPrice{}, gradeErr
+		}
+	}
+	return price, err
+}
+
+// Цена марки, действовавшая в момент t
+func (f *FuelPriceService) GetPriceAt(ctx context.Context, grade string, t time.Time) (entity.FuelPrice, error) {
+	grade = NormalizeGradeCode(grade)
+
+	if _, err := f.grades.GetByCode(ctx, grade); err != nil {
+		return entity.FuelPrice{}, err
+	}
+
+	return f.repo.GetAt(ctx, grade, t)
+}
+
+// Цены с периодами действия за промежуток (пустой grade — все марки)
+func (f *FuelPriceService) GetPriceIntervals(ctx context.Context, grade string, from, to time.Time) ([]entity.PriceInterval, error) {
+	filter := interfaces.FuelPriceFilter{
+		DateFrom: &from,
+		DateTo:   &to,
+	}
+	if grade != "" {
+		grade = NormalizeGradeCode(grade)
+		filter.Grade = &grade
+	}
+
+	return f.repo.FindIntervals(ctx, filter)
 }
 
-// Уставновка цены впервые (аккуратно, может возникнуть две активные цены)
-func (f *FuelPriceService) InitPrice(ctx context.Context, newPriceRub float64) (entity.FuelPrice, error) {
+// Активные цены всех марок
+func (f *FuelPriceService) ListActive(ctx context.Context) ([]entity.FuelPrice, error) {
+	return f.repo.ListActive(ctx)
+}
+
+// Уставновка цены марки впервые (аккуратно, может возникнуть две активные цены)
+func (f *FuelPriceService) InitPrice(ctx context.Context, grade string, newPrice entity.Money) (entity.FuelPrice, error) {
+	grade = NormalizeGradeCode(grade)
 
-	if err := f.validatePrice(newPriceRub); err != nil {
+	if err := f.limits.CheckPrice(ctx, newPrice); err != nil {
 		return entity.FuelPrice{}, err
 	}
 
-	_, err := f.repo.GetActive(ctx)
+	if _, err := f.grades.GetByCode(ctx, grade); err != nil {
+		return entity.FuelPrice{}, err
+	}
+
+	_, err := f.repo.GetActive(ctx, grade)
 	if err == nil {
 		return entity.FuelPrice{}, ErrTryUseChangePrice
 	}
@@ -39,7 +87,7 @@ func (f *FuelPriceService) InitPrice(ctx context.Context, newPriceRub float64) (
 		return entity.FuelPrice{}, err
 	}
 
-	fp := f.setPrice(newPriceRub)
+	fp := f.setPrice(grade, newPrice)
 
 	if err := f.repo.ChangePrice(ctx, fp); err != nil {
 		return entity.FuelPrice{}, err
@@ -48,42 +96,44 @@ func (f *FuelPriceService) InitPrice(ctx context.Context, newPriceRub float64) (
 	return fp, nil
 }
 
-// ChangePrice изменяет цену топлива, деактивируя предыдущую активную цену.
-func (f *FuelPriceService) ChangePrice(ctx context.Context, newPriceRub float64) (entity.FuelPrice, error) {
+// ChangePrice изменяет цену марки, деактивируя её предыдущую активную цену.
+func (f *FuelPriceService) ChangePrice(ctx context.Context, grade string, newPrice entity.Money) (entity.FuelPrice, error) {
+	return f.activateAt(ctx, NormalizeGradeCode(grade), newPrice, time.Now())
+}
 
-	if err := f.validatePrice(newPriceRub); err != nil {
+// Проверяет новую цену по лимитам относительно текущей и активирует её с момента at
+func (f *FuelPriceService) activateAt(ctx context.Context, grade string, newPrice entity.Money, at time.Time) (entity.FuelPrice, error) {
+	if err := f.limits.CheckPrice(ctx, newPrice); err != nil {
 		return entity.FuelPrice{}, err
 	}
 
-	_, err := f.repo.GetActive(ctx)
+	old, err := f.GetActive(ctx, grade)
 	if err != nil {
 		return entity.FuelPrice{}, err
 	}
 
-	newPrice := f.setPrice(newPriceRub) // добавление новой цены
+	// Проверка допустимого изменения цены за один раз
+	if err := f.limits.CheckPriceChange(ctx, old.PricePerLiter, newPrice); err != nil {
+		return entity.FuelPrice{}, err
+	}
+
+	fp := f.setPrice(grade, newPrice) // добавление новой цены
+	fp.CreatedAt = at
 
-	if err := f.repo.ActivateNewPrice(ctx, newPrice); err != nil {
+	if err := f.repo.ActivateNewPrice(ctx, fp); err != nil {
 		return entity.FuelPrice{}, err
 	}
 
-	return newPrice, nil
+	return fp, nil
 }
 
-func (f *FuelPriceService) setPrice(newPriceRub float64) entity.FuelPrice {
+func (f *FuelPriceService) setPrice(grade string, newPrice entity.Money) entity.FuelPrice {
 	return entity.FuelPrice{
 		ID:            0,
-		PricePerLiter: newPriceRub,
+		GradeCode:     grade,
+		PricePerLiter: newPrice,
 		CreatedAt:     time.Now(),
 		IsActive:      true,
 	}
 }
 
-func (f *FuelPriceService) validatePrice(price float64) error {
-	if price <= 0 {
-		return ErrPriceCanNotBeNegative
-	}
-	if price > 10000 {
-		return ErrPriceTooHigh
-	}
-	return nil
-}
@@ -2,65 +2,94 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fuelStation/internal/domain/entity"
 	"fuelStation/internal/domain/interfaces"
 	"time"
 )
 
 type CounterStateService struct {
-	repo interfaces.CounterRepository
+	repo    interfaces.CounterRepository
+	devices interfaces.DeviceRepository
 }
 
-func NewCounterStateService(repo interfaces.CounterRepository) *CounterStateService {
+func NewCounterStateService(repo interfaces.CounterRepository, devices interfaces.DeviceRepository) *CounterStateService {
 	return &CounterStateService{
-		repo: repo,
+		repo:    repo,
+		devices: devices,
 	}
 }
 
-// Обновление счетчика при заправке
-func (s *CounterStateService) UpdateCounterDuringRefuel(ctx context.Context, newValue int) (entity.CounterState, error) {
-	if err := s.validateUpdateCounter(ctx, newValue); err != nil {
-		return entity.CounterState{}, err
+// Текущее значение счётчика колонки
+func (s *CounterStateService) GetCurrent(ctx context.Context, deviceID string) (entity.CounterState, error) {
+	return s.repo.GetCurrent(ctx, deviceID)
+}
+
+// Обновление счетчика колонки при заправке
+func (s *CounterStateService) UpdateCounterDuringRefuel(ctx context.Context, deviceID string, newValue int) (entity.CounterState, error) {
+	if newValue < 0 {
+		return entity.CounterState{}, ErrCounterCanNotBeNegative
 	}
 
-	updated, err := s.UpdateCounter(ctx, newValue)
+	current, err := s.validateUpdateCounter(ctx, deviceID, newValue)
 	if err != nil {
 		return entity.CounterState{}, err
 	}
 
-	return updated, nil
+	return s.save(ctx, current, newValue)
 }
 
 // Валидация при обновлении счетчика при заправке
-func (s *CounterStateService) validateUpdateCounter(ctx context.Context, newValue int) error {
+func (s *CounterStateService) validateUpdateCounter(ctx context.Context, deviceID string, newValue int) (entity.CounterState, error) {
 
-	current, err := s.repo.GetCurrent(ctx)
+	current, err := s.repo.GetCurrent(ctx, deviceID)
 	if err != nil {
-		return err
+		return entity.CounterState{}, err
 	}
 
 	if current.CurrentValue > int64(newValue) {
-		return ErrNewValueCanNotBeSmallerThanOld
+		return entity.CounterState{}, ErrNewValueCanNotBeSmallerThanOld
 	}
 
-	return nil
+	return current, nil
 }
 
 // Обновление счетчика без валидации (пригодится если нужно будет выставить значение счетчика впервые либо после какого либо сбоя)
-func (s *CounterStateService) UpdateCounter(ctx context.Context, newValue int) (entity.CounterState, error) {
+func (s *CounterStateService) UpdateCounter(ctx context.Context, deviceID string, newValue int) (entity.CounterState, error) {
 
 	if newValue < 0 {
 		return entity.CounterState{}, ErrCounterCanNotBeNegative
 	}
 
+	// Если счётчика ещё нет, сохраняем первую запись (версия 0)
+	current, err := s.repo.GetCurrent(ctx, deviceID)
+	if errors.Is(err, ErrNotFoundCounter) {
+		// Первый счётчик заводится только для зарегистрированной колонки
+		if _, err := s.devices.GetByID(ctx, deviceID); err != nil {
+			return entity.CounterState{}, err
+		}
+		current = entity.CounterState{DeviceID: deviceID}
+	} else if err != nil {
+		return entity.CounterState{}, err
+	}
+
+	return s.save(ctx, current, newValue)
+}
+
+// Сохраняет новое значение поверх прочитанного состояния current
+func (s *CounterStateService) save(ctx context.Context, current entity.CounterState, newValue int) (entity.CounterState, error) {
 	updated := entity.CounterState{
-		Id: 0,
+		Id:           current.Id,
+		DeviceID:     current.DeviceID,
 		CurrentValue: int64(newValue),
 		UpdatedAt:    time.Now(),
+		Version:      current.Version,
 	}
 
 	if err := s.repo.Save(ctx, updated); err != nil {
 		return entity.CounterState{}, err
 	}
+
+	updated.Version++
 	return updated, nil
 }
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"fuelStation/internal/domain/entity"
+	"fuelStation/internal/domain/interfaces"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Максимальная длина ID колонки
+const maxDeviceIDLen = 64
+
+type DeviceService struct {
+	repo interfaces.DeviceRepository
+}
+
+func NewDeviceService(repo interfaces.DeviceRepository) *DeviceService {
+	return &DeviceService{
+		repo: repo,
+	}
+}
+
+// Все колонки
+func (s *DeviceService) List(ctx context.Context) ([]entity.Device, error) {
+	return s.repo.List(ctx)
+}
+
+// Колонка по ID
+func (s *DeviceService) Get(ctx context.Context, id string) (entity.Device, error) {
+	return s.repo.GetByID(ctx, NormalizeDeviceID(id))
+}
+
+// Регистрация новой колонки (сразу включена)
+func (s *DeviceService) Create(ctx context.Context, id, name string) (entity.Device, error) {
+	device := entity.Device{
+		ID:        NormalizeDeviceID(id),
+		Name:      strings.TrimSpace(name),
+		IsActive:  true,
+		CreatedAt: time.Now(),
+	}
+
+	if !validDeviceID(device.ID) || device.Name == "" {
+		return entity.Device{}, ErrInvalidDevice
+	}
+
+	if err := s.repo.Create(ctx, &device); err != nil {
+		return entity.Device{}, err
+	}
+
+	return device, nil
+}
+
+// Включение/выключение колонки (выключенная не принимает новые заправки)
+func (s *DeviceService) SetActive(ctx context.Context, id string, active bool) (entity.Device, error) {
+	id = NormalizeDeviceID(id)
+
+	if err := s.repo.SetActive(ctx, id, active); err != nil {
+		return entity.Device{}, err
+	}
+
+	return s.repo.GetByID(ctx, id)
+}
+
+// Колонка существует и включена
+func (s *DeviceService) requireActive(ctx context.Context, id string) error {
+	device, err := s.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !device.IsActive {
+		return ErrDeviceInactive
+	}
+	return nil
+}
+
+// ID колонки храним без пробелов по краям, регистр сохраняется
+func NormalizeDeviceID(id string) string {
+	return strings.TrimSpace(id)
+}
+
+// ID колонки — латинские буквы, цифры, '-' и '_'
+func validDeviceID(id string) bool {
+	if id == "" || len(id) > maxDeviceIDLen {
+		return false
+	}
+	for _, r := range id {
+		if r > unicode.MaxASCII {
+			return false
+		}
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-' && r != '_' {
+			return false
+		}
+	}
+	return true
+}
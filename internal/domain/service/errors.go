@@ -1,6 +1,9 @@
 package service
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
 	ErrNotFoundOldPrice                      = errors.New("not found old price")
@@ -14,4 +17,57 @@ var (
 	ErrCounterWasChangedDuringRefuelCreation = errors.New("counter was changed during refuel creation")
 	ErrTryUseChangePrice                     = errors.New("you already have active price, try function change price")
 	ErrNotFoundOper                          = errors.New("not found operations")
+	ErrNotFoundPrice                         = errors.New("not found price")
+	ErrNotFoundCounter                       = errors.New("not found counter state")
+	ErrCounterVersionConflict                = errors.New("counter was changed by another operation")
+	ErrPriceTooLow                           = errors.New("fuel price too low")
+	ErrAmountTooLow                          = errors.New("amount paid too low")
+	ErrPriceChangeTooLarge                   = errors.New("fuel price change too large")
+	ErrNotFoundLimits                        = errors.New("not found limits")
+	ErrInvalidLimits                         = errors.New("invalid limits: values must be non-negative, max must be positive and not less than min")
+	ErrNotFoundGrade                         = errors.New("not found fuel grade")
+	ErrGradeAlreadyExists                    = errors.New("fuel grade already exists")
+	ErrInvalidGrade                          = errors.New("invalid fuel grade: code and name are required")
+	ErrNotFoundDevice                        = errors.New("not found device")
+	ErrDeviceAlreadyExists                   = errors.New("device already exists")
+	ErrInvalidDevice                         = errors.New("invalid device: id and name are required")
+	ErrDeviceInactive                        = errors.New("device is disabled")
+	ErrNotFoundScheduledPrice                = errors.New("not found scheduled price")
+	ErrScheduledPriceNotPending              = errors.New("scheduled price is already applied or cancelled")
+	ErrScheduleInPast                        = errors.New("effective time must be in the future")
+	ErrInvalidIdempotencyKey                 = errors.New("invalid idempotency key: too long")
+	ErrIdempotencyKeyMismatch                = errors.New("idempotency key was already used with different parameters")
+	ErrDuplicateIdempotencyKey               = errors.New("idempotency key is already used")
+	ErrDispensedMoreThanPaid                 = errors.New("counter reading exceeds paid volume")
+	ErrVolumeMustBePositive                  = errors.New("volume must be positive")
+	ErrCounterReadingRequired                = errors.New("fill-up operation must be confirmed with counter reading")
+	ErrNothingDispensed                      = errors.New("nothing was dispensed, cancel the operation instead")
+	ErrInvalidRefuelMode                     = errors.New("invalid refuel mode: expected Amount, Volume or FillUp")
+	ErrInvalidPayment                        = errors.New("invalid payment: method must be Cash, Card, SBP or FuelCard, amount must be positive")
+	ErrPaymentExceedsAmount                  = errors.New("payments exceed operation amount or operation is cancelled")
+	ErrPaymentsMismatchAmount                = errors.New("payments must add up to operation amount")
+	ErrInvalidRefund                         = errors.New("invalid refund: amount must be positive, method Cash or Card, issuer is required")
+	ErrRefundExceedsDue                      = errors.New("refund exceeds amount due to customer")
+	ErrNotFoundShift                         = errors.New("not found shift")
+	ErrShiftAlreadyOpen                      = errors.New("another shift is already open")
+	ErrNoOpenShift                           = errors.New("no open shift")
+	ErrInvalidShift                          = errors.New("invalid shift: operator is required, cash can not be negative")
 )
+
+// Нарушение лимита: какое правило и какой порог сработали.
+// errors.Is(err, ErrPriceTooHigh) и т.п. продолжают работать через Unwrap
+type LimitError struct {
+	Err       error  // базовая ошибка (ErrPriceTooHigh, ErrAmountTooLow, ...)
+	Rule      string // название правила, например max_price_per_liter
+	Value     int64  // проверяемое значение
+	Threshold int64  // порог правила
+	Unit      string // единица Value и Threshold: kop или %
+}
+
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("%v: %s limit %d %s, got %d %s", e.Err, e.Rule, e.Threshold, e.Unit, e.Value, e.Unit)
+}
+
+func (e *LimitError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"fuelStation/internal/domain/entity"
+	"fuelStation/internal/domain/interfaces"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Максимальная длина кода марки (как в схеме)
+const maxGradeCodeLen = 16
+
+type FuelGradeService struct {
+	repo interfaces.FuelGradeRepository
+}
+
+func NewFuelGradeService(repo interfaces.FuelGradeRepository) *FuelGradeService {
+	return &FuelGradeService{
+		repo: repo,
+	}
+}
+
+// Все марки топлива
+func (s *FuelGradeService) List(ctx context.Context) ([]entity.FuelGrade, error) {
+	return s.repo.List(ctx)
+}
+
+// Марка по коду (код без учёта регистра)
+func (s *FuelGradeService) Get(ctx context.Context, code string) (entity.FuelGrade, error) {
+	return s.repo.GetByCode(ctx, NormalizeGradeCode(code))
+}
+
+// Добавление новой марки
+func (s *FuelGradeService) Create(ctx context.Context, code, name string) (entity.FuelGrade, error) {
+	grade := entity.FuelGrade{
+		Code:      NormalizeGradeCode(code),
+		Name:      strings.TrimSpace(name),
+		CreatedAt: time.Now(),
+	}
+
+	if !validGradeCode(grade.Code) || grade.Name == "" {
+		return entity.FuelGrade{}, ErrInvalidGrade
+	}
+
+	if err := s.repo.Create(ctx, &grade); err != nil {
+		return entity.FuelGrade{}, err
+	}
+
+	return grade, nil
+}
+
+// Коды марок храним в верхнем регистре: ai95 и AI95 — одна марка
+func NormalizeGradeCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// Код — латинские буквы и цифры
+func validGradeCode(code string) bool {
+	if code == "" || len(code) > maxGradeCodeLen {
+		return false
+	}
+	for _, r := range code {
+		if r > unicode.MaxASCII || !(unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fuelStation/internal/domain/entity"
+	"fuelStation/internal/domain/interfaces"
+	"time"
+)
+
+// Названия правил для LimitError
+const (
+	RuleMinPricePerLiter      = "min_price_per_liter"
+	RuleMaxPricePerLiter      = "max_price_per_liter"
+	RuleMinAmountPaid         = "min_amount_paid"
+	RuleMaxAmountPaid         = "max_amount_paid"
+	RuleMaxPriceChangePercent = "max_price_change_percent"
+)
+
+// Лимиты по умолчанию: цена до 10 000 ₽ за литр, оплата до 100 000 ₽
+func DefaultLimits() entity.Limits {
+	return entity.Limits{
+		MaxPricePerLiter: 10000 * entity.KopecksPerRuble,
+		MaxAmountPaid:    100000 * entity.KopecksPerRuble,
+	}
+}
+
+// Политика лимитов: текущие значения хранятся в репозитории и могут меняться
+// администратором во время работы, пока их не задали — действуют значения из конфигурации
+type LimitsPolicy struct {
+	repo     interfaces.LimitsRepository
+	defaults entity.Limits
+}
+
+func NewLimitsPolicy(repo interfaces.LimitsRepository, defaults entity.Limits) *LimitsPolicy {
+	return &LimitsPolicy{
+		repo:     repo,
+		defaults: defaults,
+	}
+}
+
+// Действующие лимиты
+func (p *LimitsPolicy) Current(ctx context.Context) (entity.Limits, error) {
+	limits, err := p.repo.Get(ctx)
+	if errors.Is(err, ErrNotFoundLimits) {
+		return p.defaults, nil
+	}
+	if err != nil {
+		return entity.Limits{}, err
+	}
+	return limits, nil
+}
+
+// Изменение лимитов администратором
+func (p *LimitsPolicy) Update(ctx context.Context, limits entity.Limits) (entity.Limits, error) {
+	if err := validateLimits(limits); err != nil {
+		return entity.Limits{}, err
+	}
+
+	limits.UpdatedAt = time.Now()
+	if err := p.repo.Save(ctx, limits); err != nil {
+		return entity.Limits{}, err
+	}
+
+	return limits, nil
+}
+
+// Проверка цены за литр
+func (p *LimitsPolicy) CheckPrice(ctx context.Context, price entity.Money) error {
+	if price <= 0 {
+		return ErrPriceCanNotBeNegative
+	}
+
+	limits, err := p.Current(ctx)
+	if err != nil {
+		return err
+	}
+
+	if limits.MinPricePerLiter > 0 && price < limits.MinPricePerLiter {
+		return newLimitError(ErrPriceTooLow, RuleMinPricePerLiter, price, limits.MinPricePerLiter)
+	}
+	if price > limits.MaxPricePerLiter {
+		return newLimitError(ErrPriceTooHigh, RuleMaxPricePerLiter, price, limits.MaxPricePerLiter)
+	}
+
+	return nil
+}
+
+// Проверка изменения цены с oldPrice на newPrice
+func (p *LimitsPolicy) CheckPriceChange(ctx context.Context, oldPrice, newPrice entity.Money) error {
+	limits, err := p.Current(ctx)
+	if err != nil {
+		return err
+	}
+
+	if limits.MaxPriceChangePercent <= 0 || oldPrice <= 0 {
+		return nil
+	}
+
+	diff := int64(newPrice - oldPrice)
+	if diff < 0 {
+		diff = -diff
+	}
+
+	// diff/old > percent/100 без деления
+	if diff*100 > limits.MaxPriceChangePercent*int64(oldPrice) {
+		return &LimitError{
+			Err:       ErrPriceChangeTooLarge,
+			Rule:      RuleMaxPriceChangePercent,
+			Value:     entity.DivRound(diff*100, int64(oldPrice)),
+			Threshold: limits.MaxPriceChangePercent,
+			Unit:      "%",
+		}
+	}
+
+	return nil
+}
+
+// Проверка суммы оплаты
+func (p *LimitsPolicy) CheckAmount(ctx context.Context, amount entity.Money) error {
+	if amount <= 0 {
+		return ErrAmountCanNotBeNegative
+	}
+
+	limits, err := p.Current(ctx)
+	if err != nil {
+		return err
+	}
+
+	if limits.MinAmountPaid > 0 && amount < limits.MinAmountPaid {
+		return newLimitError(ErrAmountTooLow, RuleMinAmountPaid, amount, limits.MinAmountPaid)
+	}
+	if amount > limits.MaxAmountPaid {
+		return newLimitError(ErrAmountTooHigh, RuleMaxAmountPaid, amount, limits.MaxAmountPaid)
+	}
+
+	return nil
+}
+
+// Проверка согласованности лимитов
+func validateLimits(l entity.Limits) error {
+	switch {
+	case l.MinPricePerLiter < 0, l.MinAmountPaid < 0, l.MaxPriceChangePercent < 0:
+		return ErrInvalidLimits
+	case l.MaxPricePerLiter <= 0, l.MaxAmountPaid <= 0:
+		return ErrInvalidLimits
+	case l.MinPricePerLiter > l.MaxPricePerLiter, l.MinAmountPaid > l.MaxAmountPaid:
+		return ErrInvalidLimits
+	}
+	return nil
+}
+
+func newLimitError(err error, rule string, value, threshold entity.Money) *LimitError {
+	return &LimitError{
+		Err:       err,
+		Rule:      rule,
+		Value:     int64(value),
+		Threshold: int64(threshold),
+		Unit:      "kop",
+	}
+}
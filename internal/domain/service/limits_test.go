@@ -0,0 +1,214 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fuelStation/internal/domain/entity"
+	"testing"
+)
+
+// Лимиты в памяти; nil — ещё не задавались
+type memoryLimitsRepo struct {
+	limits *entity.Limits
+}
+
+func (r *memoryLimitsRepo) Get(context.Context) (entity.Limits, error) {
+	if r.limits == nil {
+		return entity.Limits{}, ErrNotFoundLimits
+	}
+	return *r.limits, nil
+}
+
+func (r *memoryLimitsRepo) Save(_ context.Context, limits entity.Limits) error {
+	r.limits = &limits
+	return nil
+}
+
+func testLimitsPolicy() *LimitsPolicy {
+	return NewLimitsPolicy(&memoryLimitsRepo{limits: &entity.Limits{
+		MinPricePerLiter:      3000,
+		MaxPricePerLiter:      9000,
+		MinAmountPaid:         10000,
+		MaxAmountPaid:         500000,
+		MaxPriceChangePercent: 10,
+	}}, DefaultLimits())
+}
+
+// Проверяет базовую ошибку err, а для непустого rule — что это LimitError с нужными правилом, значением и порогом
+func checkLimitError(t *testing.T, err error, wantErr error, rule string, value, threshold int64, unit string) {
+	t.Helper()
+
+	if wantErr == nil {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return
+	}
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("error = %v, want %v", err, wantErr)
+	}
+	if rule == "" {
+		return
+	}
+
+	var limitErr *LimitError
+	if !errors.As(err, &limitErr) {
+		t.Fatalf("error %v is not a LimitError", err)
+	}
+	if limitErr.Rule != rule || limitErr.Value != value || limitErr.Threshold != threshold || limitErr.Unit != unit {
+		t.Fatalf("LimitError = {%s %d %d %s}, want {%s %d %d %s}",
+			limitErr.Rule, limitErr.Value, limitErr.Threshold, limitErr.Unit, rule, value, threshold, unit)
+	}
+}
+
+func TestCheckPrice(t *testing.T) {
+	tests := []struct {
+		name    string
+		price   entity.Money
+		wantErr error
+		rule    string
+	}{
+		{"zero", 0, ErrPriceCanNotBeNegative, ""},
+		{"negative", -1, ErrPriceCanNotBeNegative, ""},
+		{"below min", 2999, ErrPriceTooLow, RuleMinPricePerLiter},
+		{"at min", 3000, nil, ""},
+		{"at max", 9000, nil, ""},
+		{"above max", 9001, ErrPriceTooHigh, RuleMaxPricePerLiter},
+	}
+
+	policy := testLimitsPolicy()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			threshold := int64(3000)
+			if tt.rule == RuleMaxPricePerLiter {
+				threshold = 9000
+			}
+			err := policy.CheckPrice(context.Background(), tt.price)
+			checkLimitError(t, err, tt.wantErr, tt.rule, int64(tt.price), threshold, "kop")
+		})
+	}
+}
+
+func TestCheckAmount(t *testing.T) {
+	tests := []struct {
+		name    string
+		amount  entity.Money
+		wantErr error
+		rule    string
+	}{
+		{"zero", 0, ErrAmountCanNotBeNegative, ""},
+		{"negative", -100, ErrAmountCanNotBeNegative, ""},
+		{"below min", 9999, ErrAmountTooLow, RuleMinAmountPaid},
+		{"at min", 10000, nil, ""},
+		{"at max", 500000, nil, ""},
+		{"above max", 500001, ErrAmountTooHigh, RuleMaxAmountPaid},
+	}
+
+	policy := testLimitsPolicy()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			threshold := int64(10000)
+			if tt.rule == RuleMaxAmountPaid {
+				threshold = 500000
+			}
+			err := policy.CheckAmount(context.Background(), tt.amount)
+			checkLimitError(t, err, tt.wantErr, tt.rule, int64(tt.amount), threshold, "kop")
+		})
+	}
+}
+
+func TestCheckPriceChange(t *testing.T) {
+	tests := []struct {
+		name      string
+		old, new  entity.Money
+		wantErr   error
+		wantValue int64 // изменение в процентах, округлённое
+	}{
+		{"no change", 5000, 5000, nil, 0},
+		{"up to limit", 5000, 5500, nil, 0},
+		{"down to limit", 5000, 4500, nil, 0},
+		{"just above limit", 5000, 5501, ErrPriceChangeTooLarge, 10},
+		{"down above limit", 5000, 4400, ErrPriceChangeTooLarge, 12},
+		{"double", 5000, 10000, ErrPriceChangeTooLarge, 100},
+		{"no old price", 0, 9000, nil, 0},
+	}
+
+	policy := testLimitsPolicy()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := policy.CheckPriceChange(context.Background(), tt.old, tt.new)
+			checkLimitError(t, err, tt.wantErr, RuleMaxPriceChangePercent, tt.wantValue, 10, "%")
+		})
+	}
+}
+
+func TestCheckPriceChangeUnlimited(t *testing.T) {
+	policy := NewLimitsPolicy(&memoryLimitsRepo{}, DefaultLimits())
+
+	if err := policy.CheckPriceChange(context.Background(), 5000, 50000); err != nil {
+		t.Fatalf("unexpected error with no change limit: %v", err)
+	}
+}
+
+// Пока лимиты не задавались, действуют значения по умолчанию; без минимума проверяется только максимум
+func TestLimitsDefaults(t *testing.T) {
+	policy := NewLimitsPolicy(&memoryLimitsRepo{}, DefaultLimits())
+	ctx := context.Background()
+
+	if err := policy.CheckPrice(ctx, 1); err != nil {
+		t.Errorf("CheckPrice(1): %v", err)
+	}
+	checkLimitError(t, policy.CheckPrice(ctx, 1000001), ErrPriceTooHigh, RuleMaxPricePerLiter, 1000001, 1000000, "kop")
+
+	if err := policy.CheckAmount(ctx, 1); err != nil {
+		t.Errorf("CheckAmount(1): %v", err)
+	}
+	checkLimitError(t, policy.CheckAmount(ctx, 10000001), ErrAmountTooHigh, RuleMaxAmountPaid, 10000001, 10000000, "kop")
+}
+
+func TestLimitsUpdate(t *testing.T) {
+	valid := entity.Limits{MinPricePerLiter: 100, MaxPricePerLiter: 200, MinAmountPaid: 100, MaxAmountPaid: 200, MaxPriceChangePercent: 5}
+
+	tests := []struct {
+		name    string
+		change  func(l *entity.Limits)
+		wantErr error
+	}{
+		{"valid", func(l *entity.Limits) {}, nil},
+		{"min equals max", func(l *entity.Limits) { l.MinPricePerLiter = l.MaxPricePerLiter }, nil},
+		{"negative min price", func(l *entity.Limits) { l.MinPricePerLiter = -1 }, ErrInvalidLimits},
+		{"negative min amount", func(l *entity.Limits) { l.MinAmountPaid = -1 }, ErrInvalidLimits},
+		{"negative change percent", func(l *entity.Limits) { l.MaxPriceChangePercent = -1 }, ErrInvalidLimits},
+		{"zero max price", func(l *entity.Limits) { l.MaxPricePerLiter = 0; l.MinPricePerLiter = 0 }, ErrInvalidLimits},
+		{"zero max amount", func(l *entity.Limits) { l.MaxAmountPaid = 0; l.MinAmountPaid = 0 }, ErrInvalidLimits},
+		{"min price above max", func(l *entity.Limits) { l.MinPricePerLiter = 201 }, ErrInvalidLimits},
+		{"min amount above max", func(l *entity.Limits) { l.MinAmountPaid = 201 }, ErrInvalidLimits},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &memoryLimitsRepo{}
+			policy := NewLimitsPolicy(repo, DefaultLimits())
+
+			limits := valid
+			tt.change(&limits)
+
+			_, err := policy.Update(context.Background(), limits)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Update error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil && repo.limits != nil {
+				t.Fatal("invalid limits were saved")
+			}
+			if tt.wantErr == nil {
+				current, err := policy.Current(context.Background())
+				if err != nil {
+					t.Fatalf("Current: %v", err)
+				}
+				if current.MaxPricePerLiter != limits.MaxPricePerLiter || current.MinAmountPaid != limits.MinAmountPaid {
+					t.Fatalf("Current = %+v, want %+v", current, limits)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+	"fuelStation/internal/domain/interfaces"
+	"log"
+	"time"
+)
+
+// Фоновая очистка старых логов
+type LogRetentionWorker struct {
+	repo     interfaces.LogRepository
+	keepDays int           // сколько дней хранить логи
+	interval time.Duration // как часто запускать очистку
+}
+
+func NewLogRetentionWorker(repo interfaces.LogRepository, keepDays int, interval time.Duration) *LogRetentionWorker {
+	return &LogRetentionWorker{
+		repo:     repo,
+		keepDays: keepDays,
+		interval: interval,
+	}
+}
+
+// Запускает очистку сразу и далее по расписанию, пока не отменён ctx
+func (w *LogRetentionWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := w.Cleanup(ctx); err != nil {
+			log.Printf("⚠️ Ошибка очистки логов: %v\n", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Удаляет логи старше keepDays дней
+func (w *LogRetentionWorker) Cleanup(ctx context.Context) error {
+	before := time.Now().AddDate(0, 0, -w.keepDays)
+	return w.repo.DeleteOld(ctx, before)
+}
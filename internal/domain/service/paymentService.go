@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"fuelStation/internal/domain/entity"
+	"fuelStation/internal/domain/interfaces"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Способы оплаты
+const (
+	PaymentMethodCash     = "Cash"
+	PaymentMethodCard     = "Card"
+	PaymentMethodSBP      = "SBP"      // QR-код / система быстрых платежей
+	PaymentMethodFuelCard = "FuelCard" // топливная карта
+)
+
+// Максимальная длина номера внешней транзакции (как в схеме)
+const maxExternalRefLen = 128
+
+// Платежи по операциям: способы оплаты и разбивка одной операции на несколько платежей
+type PaymentService struct {
+	repo       interfaces.PaymentRepository
+	refuelRepo interfaces.RefuelOperationsRepo
+}
+
+func NewPaymentService(repo interfaces.PaymentRepository, refuelRepo interfaces.RefuelOperationsRepo) *PaymentService {
+	return &PaymentService{
+		repo:       repo,
+		refuelRepo: refuelRepo,
+	}
+}
+
+// Принять платёж по операции operationID. Вместе с принятыми ранее сумма
+// не может превышать AmountPaid (для FillUp — после подтверждения)
+func (s *PaymentService) Add(ctx context.Context, operationID string, payment entity.Payment) (entity.Payment, error) {
+	payment, err := normalizePayment(payment)
+	if err != nil {
+		return entity.Payment{}, err
+	}
+
+	operation, err := s.refuelRepo.GetByID(ctx, operationID)
+	if err != nil {
+		return entity.Payment{}, err
+	}
+
+	// Быстрая проверка; окончательная — в репозитории под блокировкой строки операции
+	if operation.Status == RefuelStatusCancelled || payment.Amount > operation.AmountPaid-operation.Paid {
+		return entity.Payment{}, ErrPaymentExceedsAmount
+	}
+
+	payment.OperationID = operation.ID
+	payment.CreatedAt = time.Now()
+
+	if err := s.repo.Create(ctx, &payment); err != nil {
+		return entity.Payment{}, err
+	}
+
+	return payment, nil
+}
+
+// Платежи по операции
+func (s *PaymentService) List(ctx context.Context, operationID string) ([]entity.Payment, error) {
+	operation, err := s.refuelRepo.GetByID(ctx, operationID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.ListByOperation(ctx, operation.ID)
+}
+
+// Проверяет платёж и приводит способ оплаты к каноническому виду
+func normalizePayment(payment entity.Payment) (entity.Payment, error) {
+	method, ok := paymentMethod(payment.Method)
+	if !ok || payment.Amount <= 0 {
+		return entity.Payment{}, ErrInvalidPayment
+	}
+	payment.Method = method
+
+	if payment.ExternalRef != nil {
+		ref := strings.TrimSpace(*payment.ExternalRef)
+		if len(ref) > maxExternalRefLen {
+			return entity.Payment{}, ErrInvalidPayment
+		}
+		payment.ExternalRef = nil
+		if ref != "" {
+			payment.ExternalRef = &ref
+		}
+	}
+
+	return payment, nil
+}
+
+// Способ оплаты без учёта регистра: sbp, SBP и Sbp — одно и то же
+func paymentMethod(method string) (string, bool) {
+	for _, m := range []string{PaymentMethodCash, PaymentMethodCard, PaymentMethodSBP, PaymentMethodFuelCard} {
+		if strings.EqualFold(strings.TrimSpace(method), m) {
+			return m, true
+		}
+	}
+	return "", false
+}
+
+// Разбивка выручки по способам оплаты (в порядке названий способов)
+func statisticsByPaymentMethod(payments []entity.Payment, refunds []entity.Refund) []PaymentMethodStatistics {
+	byMethod := make(map[string]*PaymentMethodStatistics)
+	get := func(method string) *PaymentMethodStatistics {
+		m, ok := byMethod[method]
+		if !ok {
+			m = &PaymentMethodStatistics{Method: method}
+			byMethod[method] = m
+		}
+		return m
+	}
+
+	for _, p := range payments {
+		m := get(p.Method)
+		m.PaymentCount++
+		m.Paid += p.Amount
+		m.Net += p.Amount
+	}
+	for _, rf := range refunds {
+		m := get(rf.Method)
+		m.Refunded += rf.Amount
+		m.Net -= rf.Amount
+	}
+
+	result := make([]PaymentMethodStatistics, 0, len(byMethod))
+	for _, m := range byMethod {
+		result = append(result, *m)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Method < result[j].Method })
+
+	return result
+}
+
+// Статистика по одному способу оплаты: сколько принято и сколько возвращено тем же способом
+type PaymentMethodStatistics struct {
+	Method       string       // способ оплаты
+	PaymentCount int64        // платежей
+	Paid         entity.Money // принято, копейки
+	Refunded     entity.Money // возвращено, копейки
+	Net          entity.Money // принято за вычетом возвратов
+}
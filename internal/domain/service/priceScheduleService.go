@@ -0,0 +1,220 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fuelStation/internal/domain/entity"
+	"fuelStation/internal/domain/interfaces"
+	"log"
+	"time"
+)
+
+const (
+	ScheduledPriceStatusPending   = "Pending"
+	ScheduledPriceStatusApplied   = "Applied"
+	ScheduledPriceStatusCancelled = "Cancelled"
+	ScheduledPriceStatusFailed    = "Failed" // применить не удалось, активатор её больше не берёт
+)
+
+// Запланированные смены цен
+type PriceScheduleService struct {
+	repo         interfaces.ScheduledPriceRepository
+	priceService *FuelPriceService
+	transactor   interfaces.Transactor
+	limits       *LimitsPolicy
+}
+
+func NewPriceScheduleService(repo interfaces.ScheduledPriceRepository, priceService *FuelPriceService, transactor interfaces.Transactor, limits *LimitsPolicy) *PriceScheduleService {
+	return &PriceScheduleService{
+		repo:         repo,
+		priceService: priceService,
+		transactor:   transactor,
+		limits:       limits,
+	}
+}
+
+// Запланировать цену марки с момента effectiveFrom
+func (s *PriceScheduleService) Schedule(ctx context.Context, grade string, newPrice entity.Money, effectiveFrom time.Time) (entity.ScheduledPrice, error) {
+	grade = NormalizeGradeCode(grade)
+
+	if err := s.limits.CheckPrice(ctx, newPrice); err != nil {
+		return entity.ScheduledPrice{}, err
+	}
+
+	if !effectiveFrom.After(time.Now()) {
+		return entity.ScheduledPrice{}, ErrScheduleInPast
+	}
+
+	// Цена применится через ActivateNewPrice, поэтому у марки уже должна быть цена;
+	// изменение проверяем относительно неё, при применении проверка повторится
+	current, err := s.priceService.GetActive(ctx, grade)
+	if err != nil {
+		return entity.ScheduledPrice{}, err
+	}
+	if err := s.limits.CheckPriceChange(ctx, current.PricePerLiter, newPrice); err != nil {
+		return entity.ScheduledPrice{}, err
+	}
+
+	scheduled := entity.ScheduledPrice{
+		GradeCode:     grade,
+		PricePerLiter: newPrice,
+		EffectiveFrom: effectiveFrom,
+		Status:        ScheduledPriceStatusPending,
+		CreatedAt:     time.Now(),
+	}
+
+	if err := s.repo.Create(ctx, &scheduled); err != nil {
+		return entity.ScheduledPrice{}, err
+	}
+
+	return scheduled, nil
+}
+
+// Запланированные цены (пустые grade и status — без фильтра)
+func (s *PriceScheduleService) List(ctx context.Context, grade, status string) ([]entity.ScheduledPrice, error) {
+	var filter interfaces.ScheduledPriceFilter
+	if grade != "" {
+		grade = NormalizeGradeCode(grade)
+		filter.Grade = &grade
+	}
+	if status != "" {
+		filter.Status = &status
+	}
+
+	return s.repo.Find(ctx, filter)
+}
+
+// Отмена ещё не применённой цены
+func (s *PriceScheduleService) Cancel(ctx context.Context, id string) (entity.ScheduledPrice, error) {
+	if err := s.repo.Cancel(ctx, id); err != nil {
+		return entity.ScheduledPrice{}, err
+	}
+
+	return s.repo.GetByID(ctx, id)
+}
+
+// Применяет все наступившие цены по порядку. Каждая цена применяется в своей транзакции
+// вместе с отметкой Applied, а блокировка строки не даёт другому экземпляру взять её же,
+// поэтому цена применяется ровно один раз. Цена, не прошедшая проверки (лимиты, нет марки
+// или текущей цены), помечается Failed и возвращается в failed, остальные применяются дальше.
+// При прочих ошибках цена остаётся Pending до следующей проверки
+func (s *PriceScheduleService) ApplyDue(ctx context.Context) ([]entity.ScheduledPrice, []entity.ScheduledPrice, error) {
+	var applied, failed []entity.ScheduledPrice
+
+	for {
+		scheduled, err := s.applyNext(ctx)
+		if errors.Is(err, ErrNotFoundScheduledPrice) {
+			return applied, failed, nil
+		}
+		if err != nil && (scheduled.ID == 0 || !isPriceRejected(err)) {
+			return applied, failed, err
+		}
+		if err != nil {
+			now := time.Now()
+			reason := err.Error()
+			err = s.repo.MarkFailed(ctx, scheduled.ID, now, reason)
+			// Цену успели применить или отменить в другом месте
+			if errors.Is(err, ErrScheduledPriceNotPending) {
+				continue
+			}
+			if err != nil {
+				return applied, failed, err
+			}
+
+			scheduled.Status = ScheduledPriceStatusFailed
+			scheduled.FailedAt = &now
+			scheduled.FailureReason = &reason
+			failed = append(failed, scheduled)
+			continue
+		}
+
+		applied = append(applied, scheduled)
+	}
+}
+
+// Цену нельзя применить ни сейчас, ни при повторе: повторять бессмысленно
+func isPriceRejected(err error) bool {
+	for _, target := range []error{
+		ErrNotFoundGrade,
+		ErrNotFoundOldPrice,
+		ErrPriceCanNotBeNegative,
+		ErrPriceTooLow,
+		ErrPriceTooHigh,
+		ErrPriceChangeTooLarge,
+	} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// Применяет самую раннюю наступившую цену в отдельной транзакции.
+// При ошибке применения возвращает и саму цену, чтобы её можно было пометить Failed
+func (s *PriceScheduleService) applyNext(ctx context.Context) (entity.ScheduledPrice, error) {
+	var scheduled entity.ScheduledPrice
+
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		scheduled, err = s.repo.LockNextDue(ctx, time.Now())
+		if err != nil {
+			return err
+		}
+
+		// Цена действует с момента фактического применения: до него заправки шли по старой цене.
+		// Лимиты проверяются так же, как при ручной смене цены
+		now := time.Now()
+		if _, err := s.priceService.activateAt(ctx, scheduled.GradeCode, scheduled.PricePerLiter, now); err != nil {
+			return err
+		}
+
+		scheduled.Status = ScheduledPriceStatusApplied
+		scheduled.AppliedAt = &now
+		return s.repo.MarkApplied(ctx, scheduled.ID, now)
+	})
+	if err != nil && scheduled.ID != 0 {
+		scheduled.Status = ScheduledPriceStatusPending
+		scheduled.AppliedAt = nil
+	}
+
+	return scheduled, err
+}
+
+// Фоновое применение запланированных цен
+type PriceActivator struct {
+	schedule *PriceScheduleService
+	interval time.Duration // как часто проверять наступившие цены
+}
+
+func NewPriceActivator(schedule *PriceScheduleService, interval time.Duration) *PriceActivator {
+	return &PriceActivator{
+		schedule: schedule,
+		interval: interval,
+	}
+}
+
+// Проверяет наступившие цены сразу (в том числе пропущенные, пока сервис был остановлен)
+// и далее по расписанию, пока не отменён ctx
+func (a *PriceActivator) Run(ctx context.Context) {
+	ticker := time.NewTicker(a.interval)
+	defer ticker.Stop()
+
+	for {
+		applied, failed, err := a.schedule.ApplyDue(ctx)
+		for _, p := range applied {
+			log.Printf("✅ Применена запланированная цена #%d: %s — %s ₽/л\n", p.ID, p.GradeCode, p.PricePerLiter)
+		}
+		for _, p := range failed {
+			log.Printf("❌ Не удалось применить запланированную цену #%d: %s — %s\n", p.ID, p.GradeCode, *p.FailureReason)
+		}
+		if err != nil {
+			log.Printf("⚠️ Ошибка применения запланированных цен: %v\n", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
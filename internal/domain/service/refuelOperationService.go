@@ -2,8 +2,13 @@ package service
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"fuelStation/internal/domain/entity"
 	"fuelStation/internal/domain/interfaces"
+	"sort"
+	"strings"
 	"time"
 )
 
@@ -11,172 +16,519 @@ const (
 	RefuelStatusCreated   = "Created"
 	RefuelStatusConfirmed = "Confirmed"
 	RefuelStatusCancelled = "Cancelled"
-	LitersPerCounterUnit  = 10
+	// Подтверждена, но колонка отпустила меньше оплаченного: разница должна вернуться клиенту
+	RefuelStatusPartiallyFulfilled = "PartiallyFulfilled"
+	LitersPerCounterUnit           = entity.VolumeUnitsPerLiter
 )
 
+// Тип события в логах для автоматически отменённой операции
+const EventRefuelExpired = "RefuelExpired"
+
+// Максимальная длина ключа идемпотентности (как в схеме)
+const maxIdempotencyKeyLen = 128
+
 type RefuelOperationService struct {
 	refuelRepo     interfaces.RefuelOperationsRepo
+	paymentRepo    interfaces.PaymentRepository
+	refundRepo     interfaces.RefundRepository
+	logRepo        interfaces.LogRepository
 	priceService   *FuelPriceService
 	counterService *CounterStateService
+	deviceService  *DeviceService
+	transactor     interfaces.Transactor
+	limits         *LimitsPolicy
 }
 
-func NewRefuelOperationService(refuelRepo interfaces.RefuelOperationsRepo, priceService *FuelPriceService, counterService *CounterStateService) *RefuelOperationService {
+func NewRefuelOperationService(refuelRepo interfaces.RefuelOperationsRepo, paymentRepo interfaces.PaymentRepository, refundRepo interfaces.RefundRepository, logRepo interfaces.LogRepository, priceService *FuelPriceService, counterService *CounterStateService, deviceService *DeviceService, transactor interfaces.Transactor, limits *LimitsPolicy) *RefuelOperationService {
 	return &RefuelOperationService{
 		refuelRepo:     refuelRepo,
+		paymentRepo:    paymentRepo,
+		refundRepo:     refundRepo,
+		logRepo:        logRepo,
 		priceService:   priceService,
 		counterService: counterService,
+		deviceService:  deviceService,
+		transactor:     transactor,
+		limits:         limits,
 	}
 }
 
-// Операция заправки
-func (s *RefuelOperationService) CreateRefuel(ctx context.Context, amountPaid float64, counterBeforeRefill int) (entity.RefuelOperation, error) {
+// Способ задания объёма заправки
+const (
+	RefuelModeAmount = "Amount" // на сумму: объём рассчитывается по цене
+	RefuelModeVolume = "Volume" // на заданный объём: сумма рассчитывается по цене
+	RefuelModeFillUp = "FillUp" // до полного бака: сумма определяется при подтверждении по счётчику
+)
 
-	//Валидация внесенных денег
-	if err := s.validateAmount(amountPaid); err != nil {
-		return entity.RefuelOperation{}, err
+// Приводит способ заправки к каноническому виду; пустой означает заправку на сумму
+func ParseRefuelMode(mode string) (string, error) {
+	mode = strings.TrimSpace(mode)
+	if mode == "" {
+		return RefuelModeAmount, nil
+	}
+	for _, m := range []string{RefuelModeAmount, RefuelModeVolume, RefuelModeFillUp} {
+		if strings.EqualFold(mode, m) {
+			return m, nil
+		}
 	}
+	return "", ErrInvalidRefuelMode
+}
 
-	//Получение активной цены за литр
-	priceObj, err := s.priceService.GetActive(ctx)
-	if err != nil {
+// Параметры новой операции
+type refuelRequest struct {
+	deviceID       string
+	grade          string
+	mode           string
+	amount         entity.Money  // для RefuelModeAmount
+	volume         entity.Volume // для RefuelModeVolume
+	counterBefore  int
+	idempotencyKey string
+	payments       []entity.Payment // разбивка суммы по способам оплаты (опционально)
+}
+
+// Операция заправки марки grade на колонке deviceID на сумму amountPaid.
+// Непустой idempotencyKey защищает от дублей при повторе запроса терминалом:
+// повтор с теми же параметрами возвращает исходную операцию, с другими — ErrIdempotencyKeyMismatch.
+// Непустые payments должны в сумме давать amountPaid и сохраняются вместе с операцией
+func (s *RefuelOperationService) CreateRefuel(ctx context.Context, deviceID, grade string, amountPaid entity.Money, counterBeforeRefill int, idempotencyKey string, payments []entity.Payment) (entity.RefuelOperation, error) {
+	return s.create(ctx, refuelRequest{
+		deviceID:       deviceID,
+		grade:          grade,
+		mode:           RefuelModeAmount,
+		amount:         amountPaid,
+		counterBefore:  counterBeforeRefill,
+		idempotencyKey: idempotencyKey,
+		payments:       payments,
+	})
+}
+
+// Операция заправки заданного объёма (десятые литра); сумма считается по активной цене
+func (s *RefuelOperationService) CreateRefuelByVolume(ctx context.Context, deviceID, grade string, volume entity.Volume, counterBeforeRefill int, idempotencyKey string, payments []entity.Payment) (entity.RefuelOperation, error) {
+	return s.create(ctx, refuelRequest{
+		deviceID:       deviceID,
+		grade:          grade,
+		mode:           RefuelModeVolume,
+		volume:         volume,
+		counterBefore:  counterBeforeRefill,
+		idempotencyKey: idempotencyKey,
+		payments:       payments,
+	})
+}
+
+// Заправка до полного бака. Сумма и объём неизвестны до подтверждения по счётчику,
+// сверху объём ограничен максимальной суммой оплаты из лимитов. Платежи принимаются после подтверждения
+func (s *RefuelOperationService) CreateFillUp(ctx context.Context, deviceID, grade string, counterBeforeRefill int, idempotencyKey string) (entity.RefuelOperation, error) {
+	return s.create(ctx, refuelRequest{
+		deviceID:       deviceID,
+		grade:          grade,
+		mode:           RefuelModeFillUp,
+		counterBefore:  counterBeforeRefill,
+		idempotencyKey: idempotencyKey,
+	})
+}
+
+func (s *RefuelOperationService) create(ctx context.Context, req refuelRequest) (entity.RefuelOperation, error) {
+	req.deviceID = NormalizeDeviceID(req.deviceID)
+	req.grade = NormalizeGradeCode(req.grade)
+
+	req.idempotencyKey = strings.TrimSpace(req.idempotencyKey)
+	if len(req.idempotencyKey) > maxIdempotencyKeyLen {
+		return entity.RefuelOperation{}, ErrInvalidIdempotencyKey
+	}
+
+	// Повтор уже выполненного запроса
+	if req.idempotencyKey != "" {
+		operation, err := s.replay(ctx, req)
+		if !errors.Is(err, ErrNotFoundOper) {
+			return operation, err
+		}
+	}
+
+	// Платежи: способ и сумма каждого; вместе они должны дать сумму операции
+	payments := make([]entity.Payment, 0, len(req.payments))
+	var paid entity.Money
+	for _, payment := range req.payments {
+		payment, err := normalizePayment(payment)
+		if err != nil {
+			return entity.RefuelOperation{}, err
+		}
+		payments = append(payments, payment)
+		paid += payment.Amount
+	}
+	req.payments = payments
+	if req.mode == RefuelModeFillUp && len(req.payments) > 0 {
+		return entity.RefuelOperation{}, ErrPaymentsMismatchAmount
+	}
+
+	//Валидация внесенных денег и заказанного объёма
+	switch req.mode {
+	case RefuelModeAmount:
+		if err := s.limits.CheckAmount(ctx, req.amount); err != nil {
+			return entity.RefuelOperation{}, err
+		}
+		if len(req.payments) > 0 && paid != req.amount {
+			return entity.RefuelOperation{}, ErrPaymentsMismatchAmount
+		}
+	case RefuelModeVolume:
+		if req.volume <= 0 {
+			return entity.RefuelOperation{}, ErrVolumeMustBePositive
+		}
+	}
+
+	// Колонка должна быть зарегистрирована и включена
+	if err := s.deviceService.requireActive(ctx, req.deviceID); err != nil {
 		return entity.RefuelOperation{}, err
 	}
 
-	//Получение значения счетчика
-	currentCounter, err := s.counterService.repo.GetCurrent(ctx)
+	//Получение активной цены за литр выбранной марки
+	priceObj, err := s.priceService.GetActive(ctx, req.grade)
 	if err != nil {
 		return entity.RefuelOperation{}, err
 	}
 
-	// Проверка на идентичность введенного счетчика и имеющегося
-	if currentCounter.CurrentValue != int64(counterBeforeRefill) {
-		// Обновление счетчика введенным значением
-		if _, err := s.counterService.UpdateCounter(ctx, counterBeforeRefill); err != nil {
+	// Сумма и объём (в десятых литра). Для FillUp объём — верхняя граница (на максимальную сумму оплаты),
+	// а сумма станет известна при подтверждении по счётчику
+	var (
+		amount entity.Money
+		liters entity.Volume
+	)
+	switch req.mode {
+	case RefuelModeAmount:
+		amount = req.amount
+		liters = entity.VolumeFor(amount, priceObj.PricePerLiter)
+	case RefuelModeVolume:
+		// Сумма известна только по цене, поэтому проверяется здесь, но до любых изменений
+		amount = entity.CostOf(req.volume, priceObj.PricePerLiter)
+		if err := s.limits.CheckAmount(ctx, amount); err != nil {
 			return entity.RefuelOperation{}, err
 		}
+		if len(req.payments) > 0 && paid != amount {
+			return entity.RefuelOperation{}, ErrPaymentsMismatchAmount
+		}
+		liters = req.volume
+	case RefuelModeFillUp:
+		limits, err := s.limits.Current(ctx)
+		if err != nil {
+			return entity.RefuelOperation{}, err
+		}
+		liters = entity.VolumeFor(limits.MaxAmountPaid, priceObj.PricePerLiter)
 	}
 
-	// Подсчет литров
-	liters := amountPaid / priceObj.PricePerLiter
-
 	// Подсчет состояния счетчика после (счетчик содержит десятые части литра без точки)
-	counterAfter := counterBeforeRefill + int(liters*LitersPerCounterUnit)
+	counterAfter := req.counterBefore + int(liters)
 
 	operation := entity.RefuelOperation{
 		ID:               0,
-		AmountPaid:       amountPaid,
+		DeviceID:         req.deviceID,
+		GradeCode:        priceObj.GradeCode,
+		Mode:             req.mode,
+		AmountPaid:       amount,
 		CalculatedLiters: liters,
 		PricePerLiter:    priceObj.PricePerLiter,
-		CounterBefore:    int64(counterBeforeRefill),
+		CounterBefore:    int64(req.counterBefore),
 		CounterAfter:     int64(counterAfter),
 		Status:           RefuelStatusCreated,
-		CreatedAt:        time.Now(),	
+		CreatedAt:        time.Now(),
+	}
+	if req.idempotencyKey != "" {
+		operation.IdempotencyKey = &req.idempotencyKey
 	}
 
-	// Создание новой записи
-	if err := s.refuelRepo.Create(ctx, operation); err != nil {
+	// Сверка счётчика и создание новой записи вместе с платежами. Все проверки уже пройдены,
+	// а при ошибке сохранения счётчик откатится вместе с операцией
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		//Получение значения счетчика колонки
+		currentCounter, err := s.counterService.repo.GetCurrent(ctx, req.deviceID)
+		if err != nil {
+			return err
+		}
+
+		// Проверка на идентичность введенного счетчика и имеющегося
+		if currentCounter.CurrentValue != int64(req.counterBefore) {
+			// Обновление счетчика введенным значением
+			if _, err := s.counterService.UpdateCounter(ctx, req.deviceID, req.counterBefore); err != nil {
+				return err
+			}
+		}
+
+		if err := s.refuelRepo.Create(ctx, &operation); err != nil {
+			return err
+		}
+
+		for _, payment := range req.payments {
+			payment.OperationID = operation.ID
+			payment.CreatedAt = operation.CreatedAt
+			if err := s.paymentRepo.Create(ctx, &payment); err != nil {
+				return err
+			}
+			operation.Paid += payment.Amount
+		}
+		return nil
+	})
+	if errors.Is(err, ErrDuplicateIdempotencyKey) {
+		// Параллельный повтор успел создать операцию с тем же ключом
+		return s.replay(ctx, req)
+	}
+	if err != nil {
 		return entity.RefuelOperation{}, err
 	}
 
 	return operation, nil
 }
 
-func (s *RefuelOperationService) validateAmount(amount float64) error {
-
-	if amount <= 0 {
-		return ErrAmountCanNotBeNegative
+// Операция, ранее созданная с ключом req.idempotencyKey, если параметры запроса совпадают с её параметрами
+func (s *RefuelOperationService) replay(ctx context.Context, req refuelRequest) (entity.RefuelOperation, error) {
+	operation, err := s.refuelRepo.GetByIdempotencyKey(ctx, req.idempotencyKey)
+	if err != nil {
+		return entity.RefuelOperation{}, err
 	}
 
-	if amount > 100000 {
-		return ErrAmountTooHigh
+	// Терминал может повторить запрос с ID колонки в другом регистре
+	same := strings.EqualFold(operation.DeviceID, req.deviceID) &&
+		operation.GradeCode == req.grade &&
+		operation.Mode == req.mode &&
+		operation.CounterBefore == int64(req.counterBefore)
+	switch req.mode {
+	case RefuelModeAmount:
+		same = same && operation.AmountPaid == req.amount
+	case RefuelModeVolume:
+		same = same && operation.CalculatedLiters == req.volume
+	}
+	if !same {
+		return entity.RefuelOperation{}, ErrIdempotencyKeyMismatch
 	}
 
-	return nil
+	return operation, nil
 }
 
-// Подтверждает операцию и обновляет счётчик
+// Подтверждает операцию и обновляет счётчик (счётчик и статус меняются в одной транзакции)
 func (s *RefuelOperationService) ConfirmRefuel(ctx context.Context, id string) (entity.RefuelOperation, error) {
+	var operation entity.RefuelOperation
 
-	// Находит операцию по айди
-	operation, err := s.refuelRepo.GetByID(ctx, id)
-	if err != nil {
-		return entity.RefuelOperation{}, err
-	}
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Находит операцию по айди
+		var err error
+		operation, err = s.refuelRepo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
 
-	// Проверка на соответствие статуса
-	if operation.Status != RefuelStatusCreated {
-		return entity.RefuelOperation{}, ErrInvalidOperationStatus
-	}
+		// Проверка на соответствие статуса
+		if operation.Status != RefuelStatusCreated {
+			return ErrInvalidOperationStatus
+		}
 
-	//  Проверяем что текущий счётчик не изменился
-	current, err := s.counterService.repo.GetCurrent(ctx)
-	if err != nil {
-		return entity.RefuelOperation{}, err
-	}
+		// Сколько отпущено до полного бака, известно только по счётчику
+		if operation.Mode == RefuelModeFillUp {
+			return ErrCounterReadingRequired
+		}
 
-	// Если текущий != counterBefore (из создания), что-то не так
-	if current.CurrentValue != operation.CounterBefore {
-		return entity.RefuelOperation{}, ErrCounterWasChangedDuringRefuelCreation
-	}
+		//  Проверяем что текущий счётчик колонки не изменился
+		current, err := s.counterService.repo.GetCurrent(ctx, operation.DeviceID)
+		if err != nil {
+			return err
+		}
 
-	// Обновление счетчика
-	counterAfterInt := int(operation.CounterAfter)
-	if _, err := s.counterService.UpdateCounterDuringRefuel(ctx, counterAfterInt); err != nil {
-		return entity.RefuelOperation{}, err
-	}
+		// Если текущий != counterBefore (из создания), что-то не так
+		if current.CurrentValue != operation.CounterBefore {
+			return ErrCounterWasChangedDuringRefuelCreation
+		}
 
-	// Обновление статуса
-	if err := s.refuelRepo.UpdateStatus(ctx, id, RefuelStatusConfirmed, nil); err != nil {
+		// Обновление счетчика (версия current защищает от одновременного подтверждения)
+		if _, err := s.counterService.save(ctx, current, int(operation.CounterAfter)); err != nil {
+			return err
+		}
+
+		// Обновление статуса: если операцию успели отменить, подтверждение откатится
+		return s.refuelRepo.UpdateStatus(ctx, id, RefuelStatusConfirmed, []string{RefuelStatusCreated}, nil)
+	})
+	if err != nil {
 		return entity.RefuelOperation{}, err
 	}
 
 	// Для вывода
 	operation.Status = RefuelStatusConfirmed
+	confirmedAt := time.Now()
+	operation.ConfirmedAt = &confirmedAt
 
 	return operation, nil
 }
 
-// Отменяет операцию с указанием причины
-func (s *RefuelOperationService) CancelRefuel(ctx context.Context, id string, reason string) (entity.RefuelOperation, error) {
+// Подтверждает операцию по фактическому показанию счётчика после заправки counterAfter.
+// Если колонка отпустила меньше рассчитанного (пистолет повешен, бак полон), операция
+// становится PartiallyFulfilled, а стоимость недолитого топлива записывается как сумма к возврату.
+// Для FillUp сумма операции считается по отпущенному объёму. Если не отпущено ничего, возвращается
+// ErrNothingDispensed: такую операцию нужно отменить
+func (s *RefuelOperationService) ConfirmRefuelWithCounter(ctx context.Context, id string, counterAfter int) (entity.RefuelOperation, error) {
+	var operation entity.RefuelOperation
+
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		operation, err = s.refuelRepo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if operation.Status != RefuelStatusCreated {
+			return ErrInvalidOperationStatus
+		}
+
+		current, err := s.counterService.repo.GetCurrent(ctx, operation.DeviceID)
+		if err != nil {
+			return err
+		}
+		if current.CurrentValue != operation.CounterBefore {
+			return ErrCounterWasChangedDuringRefuelCreation
+		}
 
-	// Поиск операции
-	operation, err := s.refuelRepo.GetByID(ctx, id)
+		// Фактическое показание не может быть меньше начального и больше оплаченного
+		if int64(counterAfter) < operation.CounterBefore {
+			return ErrNewValueCanNotBeSmallerThanOld
+		}
+		if int64(counterAfter) > operation.CounterAfter {
+			return ErrDispensedMoreThanPaid
+		}
+
+		dispensed := entity.Volume(int64(counterAfter) - operation.CounterBefore)
+		operation.DispensedLiters = &dispensed
+		operation.CounterAfter = int64(counterAfter)
+		operation.Status = RefuelStatusConfirmed
+
+		// Ничего не отпущено — операцию нужно отменить, тогда вернётся вся оплата
+		if dispensed == 0 {
+			return ErrNothingDispensed
+		}
+
+		if operation.Mode == RefuelModeFillUp {
+			// До полного бака: клиент платит за фактически отпущенное
+			operation.CalculatedLiters = dispensed
+			operation.AmountPaid = entity.CostOf(dispensed, operation.PricePerLiter)
+		} else if dispensed != operation.CalculatedLiters {
+			// Возвращается стоимость недолитого объёма. Остаток от округления объёма вниз
+			// (меньше стоимости десятой литра) остаётся у станции, как и при полной заправке
+			operation.Status = RefuelStatusPartiallyFulfilled
+			operation.RefundDue = entity.CostOf(operation.CalculatedLiters, operation.PricePerLiter) -
+				entity.CostOf(dispensed, operation.PricePerLiter)
+		}
+
+		if _, err := s.counterService.save(ctx, current, counterAfter); err != nil {
+			return err
+		}
+
+		return s.refuelRepo.UpdateDispensed(ctx, operation)
+	})
 	if err != nil {
 		return entity.RefuelOperation{}, err
 	}
 
-	// Проверка на соответствие статуса
-	if operation.Status != RefuelStatusConfirmed && operation.Status != RefuelStatusCreated {
-		return entity.RefuelOperation{}, ErrInvalidOperationStatus
-	}
+	confirmedAt := time.Now()
+	operation.ConfirmedAt = &confirmedAt
 
-	// Скрутить счетчик обратно
-	if operation.Status == RefuelStatusConfirmed {
-		counter, err := s.counterService.repo.GetCurrent(ctx)
+	return operation, nil
+}
+
+// Отменяет операцию с указанием причины (откат счётчика и статус меняются в одной транзакции).
+// Подтверждённую (в том числе частично) тоже можно отменить. Вся оплаченная сумма
+// становится суммой к возврату (уже выданные возвраты из неё не пропадают)
+func (s *RefuelOperationService) CancelRefuel(ctx context.Context, id string, reason string) (entity.RefuelOperation, error) {
+	var operation entity.RefuelOperation
+
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Поиск операции
+		var err error
+		operation, err = s.refuelRepo.GetByID(ctx, id)
 		if err != nil {
-			return entity.RefuelOperation{}, err
+			return err
 		}
-		newCounterValue := int(counter.CurrentValue)-int(operation.CalculatedLiters*LitersPerCounterUnit)
-		if _, errr := s.counterService.UpdateCounter(ctx, newCounterValue); errr != nil {
-			return entity.RefuelOperation{}, errr
+
+		// Проверка на соответствие статуса
+		completed := operation.Status == RefuelStatusConfirmed || operation.Status == RefuelStatusPartiallyFulfilled
+		if operation.Status != RefuelStatusCreated && !completed {
+			return ErrInvalidOperationStatus
 		}
-	}
 
-	// Обновление статуса
-	if err := s.refuelRepo.UpdateStatus(ctx, id, RefuelStatusCancelled, &reason); err != nil {
+		// Скрутить счетчик обратно
+		if completed {
+			counter, err := s.counterService.repo.GetCurrent(ctx, operation.DeviceID)
+			if err != nil {
+				return err
+			}
+			// Откатываем ровно то, на что счётчик сдвинулся при подтверждении
+			newCounterValue := int(counter.CurrentValue - (operation.CounterAfter - operation.CounterBefore))
+			if newCounterValue < 0 {
+				return ErrCounterCanNotBeNegative
+			}
+			if _, err := s.counterService.save(ctx, counter, newCounterValue); err != nil {
+				return err
+			}
+		}
+
+		// Обновление статуса, только если его не сменили после чтения операции
+		return s.refuelRepo.UpdateStatus(ctx, id, RefuelStatusCancelled, []string{operation.Status}, &reason)
+	})
+	if err != nil {
 		return entity.RefuelOperation{}, err
 	}
 
 	operation.Status = RefuelStatusCancelled
+	operation.RefundDue = operation.AmountPaid
 	p := time.Now()
 	operation.CancelledAt = &p
+	operation.CancelReason = &reason
 
 	return operation, nil
 }
 
-// Проверка наличия незавершенных операций
-func (s *RefuelOperationService) HasPendingOperations(ctx context.Context) (bool, error) {
-	operations, err := s.GetPendingOperations(ctx)
+// Отменяет операцию, зависшую в Created: она ещё не подтверждена, поэтому счётчик откатывать
+// не нужно, как и в CancelRefuel. Отмена условная (всё ещё Created и создана не позже before),
+// иначе возвращается ErrInvalidOperationStatus. Отмена и запись в лог идут в одной транзакции
+func (s *RefuelOperationService) CancelExpired(ctx context.Context, id int64, before time.Time, reason string) (entity.RefuelOperation, error) {
+	var operation entity.RefuelOperation
+
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		operation, err = s.refuelRepo.CancelExpired(ctx, id, before, reason)
+		if err != nil {
+			return err
+		}
+
+		meta, err := json.Marshal(map[string]any{
+			"operation_id":   operation.ID,
+			"created_at":     operation.CreatedAt,
+			"expired_before": before,
+		})
+		if err != nil {
+			return err
+		}
+
+		return s.logRepo.Create(ctx, &entity.LogRecord{
+			DeviceID:  operation.DeviceID,
+			Level:     "WARNING",
+			EventType: EventRefuelExpired,
+			Message:   fmt.Sprintf("Операция #%d отменена: %s", operation.ID, reason),
+			Meta:      string(meta),
+			CreatedAt: time.Now(),
+		})
+	})
+	if err != nil {
+		return entity.RefuelOperation{}, err
+	}
+
+	return operation, nil
+}
+
+// Операции в статусе Created, созданные не позже before
+func (s *RefuelOperationService) GetExpiredOperations(ctx context.Context, before time.Time) ([]entity.RefuelOperation, error) {
+	status := RefuelStatusCreated
+	return s.refuelRepo.Find(ctx, interfaces.RefuelFilter{
+		Status: &status,
+		DateTo: &before,
+	})
+}
+
+// Проверка наличия незавершенных операций на колонке (пустой deviceID — на всех)
+func (s *RefuelOperationService) HasPendingOperations(ctx context.Context, deviceID string) (bool, error) {
+	operations, err := s.GetPendingOperations(ctx, deviceID)
 	if err != nil {
 		return false, err
 	}
@@ -184,35 +536,119 @@ func (s *RefuelOperationService) HasPendingOperations(ctx context.Context) (bool
 	return len(operations) > 0, nil
 }
 
-// Получение всех операций в статусе Created (незавершенных)
-func (s *RefuelOperationService) GetPendingOperations(ctx context.Context) ([]entity.RefuelOperation, error) {
+// Получение всех операций в статусе Created (незавершенных) на колонке (пустой deviceID — на всех)
+func (s *RefuelOperationService) GetPendingOperations(ctx context.Context, deviceID string) ([]entity.RefuelOperation, error) {
 
 	status := RefuelStatusCreated
 	filter := interfaces.RefuelFilter{
 		Status: &status,
 	}
+	if deviceID != "" {
+		filter.DeviceID = &deviceID
+	}
 
 	return s.refuelRepo.Find(ctx, filter)
 }
 
-// Получить заработанные деньги за период
-func (s *RefuelOperationService) GetTotalRevenue(ctx context.Context, from, to time.Time) (float64, error) {
-	var total float64
-	status := RefuelStatusConfirmed
+// Получить заработанные деньги за период по маркам
+func (s *RefuelOperationService) GetTotalRevenueByGrade(ctx context.Context, from, to time.Time) (map[string]entity.Money, error) {
+	op, err := s.confirmedOperations(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	totals := make(map[string]entity.Money)
+	for _, operation := range op {
+		totals[operation.GradeCode] += revenueOf(operation)
+	}
+
+	return totals, nil
+}
+
+// Получить потраченные литры за период по маркам
+func (s *RefuelOperationService) GetTotalLitersByGrade(ctx context.Context, from, to time.Time) (map[string]entity.Volume, error) {
+	op, err := s.confirmedOperations(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	totals := make(map[string]entity.Volume)
+	for _, operation := range op {
+		totals[operation.GradeCode] += soldVolume(operation)
+	}
+
+	return totals, nil
+}
 
+// Подтверждённые операции за период (в том числе выполненные частично)
+func (s *RefuelOperationService) confirmedOperations(ctx context.Context, from, to time.Time) ([]entity.RefuelOperation, error) {
 	filter := interfaces.RefuelFilter{
 		DateFrom: &from,
 		DateTo:   &to,
-		Status:   &status,
+		Statuses: []string{RefuelStatusConfirmed, RefuelStatusPartiallyFulfilled},
+	}
+
+	return s.refuelRepo.Find(ctx, filter)
+}
+
+// На сколько операции сдвинули счётчики колонок за период: объём подтверждённых за период
+// минус объём откаченных отменой за период (подтверждённых раньше или в этот же период)
+func (s *RefuelOperationService) dispensedByDevice(ctx context.Context, from, to time.Time) (map[string]entity.Volume, error) {
+	confirmed, err := s.refuelRepo.Find(ctx, interfaces.RefuelFilter{
+		ConfirmedFrom: &from,
+		ConfirmedTo:   &to,
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	op, err := s.refuelRepo.Find(ctx, filter)
+	cancelled, err := s.refuelRepo.Find(ctx, interfaces.RefuelFilter{
+		CancelledFrom: &from,
+		CancelledTo:   &to,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	dispensed := make(map[string]entity.Volume)
+	for _, operation := range confirmed {
+		dispensed[operation.DeviceID] += soldVolume(operation)
+	}
+	for _, operation := range cancelled {
+		// Отмена неподтверждённой операции счётчик не трогает
+		if operation.ConfirmedAt != nil {
+			dispensed[operation.DeviceID] -= soldVolume(operation)
+		}
+	}
+
+	return dispensed, nil
+}
+
+// Отпущенный объём: фактический, если подтверждено по счётчику, иначе рассчитанный
+func soldVolume(operation entity.RefuelOperation) entity.Volume {
+	if operation.DispensedLiters != nil {
+		return *operation.DispensedLiters
+	}
+	return operation.CalculatedLiters
+}
+
+// Выручка по операции: оплата за вычетом суммы, которую нужно вернуть клиенту.
+// Возврат вычитается сразу, даже если ещё не выдан: эти деньги станции не принадлежат
+func revenueOf(operation entity.RefuelOperation) entity.Money {
+	return operation.AmountPaid - operation.RefundDue
+}
+
+// Получить заработанные деньги за период (за вычетом возвратов клиентам)
+func (s *RefuelOperationService) GetTotalRevenue(ctx context.Context, from, to time.Time) (entity.Money, error) {
+	var total entity.Money
+
+	op, err := s.confirmedOperations(ctx, from, to)
 	if err != nil {
 		return 0, err
 	}
 
 	for _, operation := range op {
-		total += operation.AmountPaid
+		total += revenueOf(operation)
 	}
 
 	return total, nil
@@ -224,14 +660,7 @@ func (s *RefuelOperationService) GetStatistics(
 	from, to time.Time,
 ) (RefuelStatistics, error) {
 
-	status := RefuelStatusConfirmed
-	filter := interfaces.RefuelFilter{
-		DateFrom: &from,
-		DateTo:   &to,
-		Status:   &status,
-	}
-
-	op, err := s.refuelRepo.Find(ctx, filter)
+	op, err := s.confirmedOperations(ctx, from, to)
 	if err != nil {
 		return RefuelStatistics{}, err
 	}
@@ -248,19 +677,25 @@ func (s *RefuelOperationService) GetStatistics(
 	}
 
 	var (
-		revenue, liters, averageLit, averageAmount float64
-		confirmed                                  int64
+		revenue, averageAmount entity.Money
+		liters, averageLit     entity.Volume
+		confirmed, partial     int64
 	)
 
 	for _, oper := range op {
-		revenue += oper.AmountPaid
-		liters += oper.CalculatedLiters
+		revenue += revenueOf(oper)
+		liters += soldVolume(oper)
+		stats.TotalRefunded += oper.Refunded
+		stats.OutstandingRefunds += oper.RefundDue - oper.Refunded
 		confirmed++
+		if oper.Status == RefuelStatusPartiallyFulfilled {
+			partial++
+		}
 	}
 
 	if confirmed > 0 {
-		averageLit = liters / float64(confirmed)
-		averageAmount = revenue / float64(confirmed)
+		averageLit = entity.Volume(entity.DivRound(int64(liters), confirmed))
+		averageAmount = entity.Money(entity.DivRound(int64(revenue), confirmed))
 	}
 
 	cStatus := RefuelStatusCancelled
@@ -293,73 +728,118 @@ func (s *RefuelOperationService) GetStatistics(
 	stats.AverageLiters = averageLit
 	stats.AverageAmount = averageAmount
 	stats.ConfirmedCount = confirmed
+	stats.PartialCount = partial
 	stats.CancelledCount = int64(len(cancelledOp))
 	stats.PendingCount = int64(len(createdOp))
+	stats.ByGrade = statisticsByGrade(op)
+
+	// Разбивка по способам оплаты для сверки кассы
+	ids := make([]int64, 0, len(op))
+	for _, oper := range op {
+		ids = append(ids, oper.ID)
+		stats.Unallocated += oper.AmountPaid - oper.Paid
+	}
+
+	payments, err := s.paymentRepo.ListByOperations(ctx, ids)
+	if err != nil {
+		return RefuelStatistics{}, err
+	}
+	refunds, err := s.refundRepo.ListByOperations(ctx, ids)
+	if err != nil {
+		return RefuelStatistics{}, err
+	}
+	stats.ByPaymentMethod = statisticsByPaymentMethod(payments, refunds)
 
 	return stats, nil
 }
 
-// Получить среднюю цену за литр за промежуток
-func (s *RefuelOperationService) GetAveragePricePerLiter(ctx context.Context, from, to time.Time) (float64, error) {
-	status := RefuelStatusConfirmed
-	filter := interfaces.RefuelFilter{
-		DateFrom: &from,
-		DateTo:   &to,
-		Status:   &status,
+// Разбивка подтверждённых операций по маркам (в порядке кодов марок)
+func statisticsByGrade(operations []entity.RefuelOperation) []GradeStatistics {
+	byCode := make(map[string]*GradeStatistics)
+	for _, oper := range operations {
+		g, ok := byCode[oper.GradeCode]
+		if !ok {
+			g = &GradeStatistics{GradeCode: oper.GradeCode}
+			byCode[oper.GradeCode] = g
+		}
+		g.ConfirmedCount++
+		g.TotalRevenue += revenueOf(oper)
+		g.TotalLiters += soldVolume(oper)
 	}
 
-	operations, err := s.refuelRepo.Find(ctx, filter)
+	result := make([]GradeStatistics, 0, len(byCode))
+	for _, g := range byCode {
+		result = append(result, *g)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].GradeCode < result[j].GradeCode })
+
+	return result
+}
+
+// Получить среднюю цену за литр за промежуток
+func (s *RefuelOperationService) GetAveragePricePerLiter(ctx context.Context, from, to time.Time) (entity.Money, error) {
+	operations, err := s.confirmedOperations(ctx, from, to)
 	if err != nil {
 		return 0, err
 	}
 
-	var sum float64
+	var sum int64
 
 	if len(operations) < 1 {
 		return 0, ErrNotFoundOper
 	}
 
 	for _, op := range operations {
-		sum += op.PricePerLiter
+		sum += int64(op.PricePerLiter)
 	}
 
-	avr := sum / float64(len(operations))
+	avr := entity.Money(entity.DivRound(sum, int64(len(operations))))
 
 	return avr, nil
 }
 
 // Получить потраченные литры за промежуток
-func (s *RefuelOperationService) GetTotalLiters(ctx context.Context, from, to time.Time) (float64, error) {
-	var total float64
-	status := RefuelStatusConfirmed
-
-	filter := interfaces.RefuelFilter{
-		DateFrom: &from,
-		DateTo:   &to,
-		Status:   &status,
-	}
+func (s *RefuelOperationService) GetTotalLiters(ctx context.Context, from, to time.Time) (entity.Volume, error) {
+	var total entity.Volume
 
-	operations, err := s.refuelRepo.Find(ctx, filter)
+	operations, err := s.confirmedOperations(ctx, from, to)
 	if err != nil {
 		return 0, err
 	}
 
 	for _, op := range operations {
-		total += op.CalculatedLiters
+		total += soldVolume(op)
 	}
 
 	return total, nil
 }
 
 type RefuelStatistics struct {
-	TotalOperations int64     // всего операций
-	TotalRevenue    float64   // рубли
-	TotalLiters     float64   // литры
-	AverageLiters   float64   // средний размер заправки
-	AverageAmount   float64   // средняя сумма
-	ConfirmedCount  int64     // подтверждённых
-	CancelledCount  int64     // отменено
-	PendingCount    int64     // ожидают подтверждения
-	StartDate       time.Time // начало периода
-	EndDate         time.Time // конец периода
+	TotalOperations    int64             // всего операций
+	TotalRevenue       entity.Money      // копейки, за вычетом возвратов
+	TotalRefunded      entity.Money      // выдано возвратов
+	OutstandingRefunds entity.Money      // возвраты, которые ещё нужно выдать
+	TotalLiters        entity.Volume     // десятые литра
+	AverageLiters      entity.Volume     // средний размер заправки
+	AverageAmount      entity.Money      // средняя сумма
+	ConfirmedCount     int64             // подтверждённых
+	PartialCount       int64             // из них выполнено частично
+	CancelledCount     int64             // отменено
+	PendingCount       int64             // ожидают подтверждения
+	StartDate          time.Time         // начало периода
+	EndDate            time.Time         // конец периода
+	ByGrade            []GradeStatistics // подтверждённые операции по маркам
+
+	// Подтверждённые операции по способам оплаты.
+	// Net по всем способам + Unallocated - OutstandingRefunds = TotalRevenue
+	ByPaymentMethod []PaymentMethodStatistics
+	Unallocated     entity.Money // сумма операций, не разнесённая по платежам
+}
+
+// Статистика по одной марке топлива
+type GradeStatistics struct {
+	GradeCode      string        // марка
+	ConfirmedCount int64         // подтверждённых операций
+	TotalRevenue   entity.Money  // копейки
+	TotalLiters    entity.Volume // десятые литра
 }
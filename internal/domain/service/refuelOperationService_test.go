@@ -0,0 +1,234 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fuelStation/internal/domain/entity"
+	"fuelStation/internal/domain/interfaces"
+	"strconv"
+	"testing"
+)
+
+// Транзакция без базы: fn выполняется как есть
+type noTxTransactor struct{}
+
+func (noTxTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// Операции в памяти; нужные тестам методы переопределены, остальные не вызываются
+type memoryRefuelRepo struct {
+	interfaces.RefuelOperationsRepo
+	operations map[string]entity.RefuelOperation
+}
+
+func (r *memoryRefuelRepo) Create(_ context.Context, operation *entity.RefuelOperation) error {
+	operation.ID = int64(len(r.operations) + 1)
+	r.operations[strconv.FormatInt(operation.ID, 10)] = *operation
+	return nil
+}
+
+func (r *memoryRefuelRepo) GetByID(_ context.Context, id string) (entity.RefuelOperation, error) {
+	operation, ok := r.operations[id]
+	if !ok {
+		return entity.RefuelOperation{}, ErrNotFoundOper
+	}
+	return operation, nil
+}
+
+func (r *memoryRefuelRepo) UpdateDispensed(_ context.Context, operation entity.RefuelOperation) error {
+	for id, stored := range r.operations {
+		if stored.ID == operation.ID {
+			r.operations[id] = operation
+			return nil
+		}
+	}
+	return ErrNotFoundOper
+}
+
+// Счётчик одной колонки в памяти
+type memoryCounterRepo struct {
+	state entity.CounterState
+}
+
+func (r *memoryCounterRepo) GetCurrent(context.Context, string) (entity.CounterState, error) {
+	return r.state, nil
+}
+
+func (r *memoryCounterRepo) Save(_ context.Context, state entity.CounterState) error {
+	if state.Version != r.state.Version {
+		return ErrCounterVersionConflict
+	}
+	state.Version++
+	r.state = state
+	return nil
+}
+
+func TestConfirmRefuelWithCounter(t *testing.T) {
+	const (
+		price         entity.Money  = 5499
+		counterBefore int64         = 10000
+		calculated    entity.Volume = 181 // на 1000 ₽ по 54.99 ₽/л, стоит 995.32 ₽
+	)
+
+	tests := []struct {
+		name       string
+		mode       string
+		amount     entity.Money
+		dispensed  entity.Volume
+		wantStatus string
+		wantAmount entity.Money
+		wantRefund entity.Money
+		wantErr    error
+	}{
+		{"amount full", RefuelModeAmount, 100000, calculated, RefuelStatusConfirmed, 100000, 0, nil},
+		{"amount one unit short", RefuelModeAmount, 100000, calculated - 1, RefuelStatusPartiallyFulfilled, 100000, 550, nil},
+		{"amount half", RefuelModeAmount, 100000, 90, RefuelStatusPartiallyFulfilled, 100000, 99532 - 49491, nil},
+		{"amount one unit", RefuelModeAmount, 100000, 1, RefuelStatusPartiallyFulfilled, 100000, 99532 - 550, nil},
+		{"amount nothing", RefuelModeAmount, 100000, 0, "", 0, 0, ErrNothingDispensed},
+		{"amount more than paid", RefuelModeAmount, 100000, calculated + 1, "", 0, 0, ErrDispensedMoreThanPaid},
+		{"volume full", RefuelModeVolume, 99532, calculated, RefuelStatusConfirmed, 99532, 0, nil},
+		{"volume one unit short", RefuelModeVolume, 99532, calculated - 1, RefuelStatusPartiallyFulfilled, 99532, 550, nil},
+		{"fill up", RefuelModeFillUp, 0, 90, RefuelStatusConfirmed, 49491, 0, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &memoryRefuelRepo{operations: map[string]entity.RefuelOperation{
+				"1": {
+					ID:               1,
+					DeviceID:         "pump-1",
+					Mode:             tt.mode,
+					AmountPaid:       tt.amount,
+					CalculatedLiters: calculated,
+					PricePerLiter:    price,
+					CounterBefore:    counterBefore,
+					CounterAfter:     counterBefore + int64(calculated),
+					Status:           RefuelStatusCreated,
+				},
+			}}
+			counters := &memoryCounterRepo{state: entity.CounterState{DeviceID: "pump-1", CurrentValue: counterBefore, Version: 1}}
+			s := &RefuelOperationService{
+				refuelRepo:     repo,
+				counterService: NewCounterStateService(counters, nil),
+				transactor:     noTxTransactor{},
+			}
+
+			operation, err := s.ConfirmRefuelWithCounter(context.Background(), "1", int(counterBefore+int64(tt.dispensed)))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if counters.state.CurrentValue != counterBefore {
+					t.Fatalf("counter = %d after rejected confirm, want %d", counters.state.CurrentValue, counterBefore)
+				}
+				return
+			}
+
+			if operation.Status != tt.wantStatus || operation.AmountPaid != tt.wantAmount || operation.RefundDue != tt.wantRefund {
+				t.Fatalf("operation = {%s paid %d refund %d}, want {%s paid %d refund %d}",
+					operation.Status, operation.AmountPaid, operation.RefundDue, tt.wantStatus, tt.wantAmount, tt.wantRefund)
+			}
+			if operation.DispensedLiters == nil || *operation.DispensedLiters != tt.dispensed {
+				t.Fatalf("dispensed = %v, want %d", operation.DispensedLiters, tt.dispensed)
+			}
+			if counters.state.CurrentValue != counterBefore+int64(tt.dispensed) {
+				t.Fatalf("counter = %d, want %d", counters.state.CurrentValue, counterBefore+int64(tt.dispensed))
+			}
+			if stored := repo.operations["1"]; stored.RefundDue != tt.wantRefund || stored.Status != tt.wantStatus {
+				t.Fatalf("stored operation = {%s refund %d}, want {%s refund %d}", stored.Status, stored.RefundDue, tt.wantStatus, tt.wantRefund)
+			}
+		})
+	}
+}
+
+// Одна включённая колонка
+type activeDeviceRepo struct {
+	interfaces.DeviceRepository
+}
+
+func (activeDeviceRepo) GetByID(_ context.Context, id string) (entity.Device, error) {
+	return entity.Device{ID: id, IsActive: true}, nil
+}
+
+// Одна активная цена для любой марки
+type fixedPriceRepo struct {
+	interfaces.FuelPriceRepository
+	price entity.Money
+}
+
+func (r fixedPriceRepo) GetActive(_ context.Context, grade string) (entity.FuelPrice, error) {
+	return entity.FuelPrice{GradeCode: grade, PricePerLiter: r.price, IsActive: true}, nil
+}
+
+type memoryPaymentRepo struct {
+	interfaces.PaymentRepository
+	payments []entity.Payment
+}
+
+func (r *memoryPaymentRepo) Create(_ context.Context, payment *entity.Payment) error {
+	r.payments = append(r.payments, *payment)
+	return nil
+}
+
+// Отклонённый запрос не должен менять счётчик колонки, даже если терминал прислал другое показание
+func TestCreateRefuelRejectedKeepsCounter(t *testing.T) {
+	const (
+		stored int64 = 10000
+		sent         = 12000 // показание от терминала, отличное от сохранённого
+	)
+	card := func(amount entity.Money) []entity.Payment {
+		return []entity.Payment{{Method: PaymentMethodCard, Amount: amount}}
+	}
+
+	tests := []struct {
+		name    string
+		create  func(s *RefuelOperationService) (entity.RefuelOperation, error)
+		wantErr error
+	}{
+		{"volume above max amount", func(s *RefuelOperationService) (entity.RefuelOperation, error) {
+			return s.CreateRefuelByVolume(context.Background(), "pump-1", "ai95", 1000, sent, "", nil) // 100 л на 5499 ₽
+		}, ErrAmountTooHigh},
+		{"amount payments mismatch", func(s *RefuelOperationService) (entity.RefuelOperation, error) {
+			return s.CreateRefuel(context.Background(), "pump-1", "ai95", 100000, sent, "", card(90000))
+		}, ErrPaymentsMismatchAmount},
+		{"volume payments mismatch", func(s *RefuelOperationService) (entity.RefuelOperation, error) {
+			return s.CreateRefuelByVolume(context.Background(), "pump-1", "ai95", 200, sent, "", card(100000)) // стоит 1099.80 ₽
+		}, ErrPaymentsMismatchAmount},
+		{"volume accepted", func(s *RefuelOperationService) (entity.RefuelOperation, error) {
+			return s.CreateRefuelByVolume(context.Background(), "pump-1", "ai95", 200, sent, "", card(109980))
+		}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &memoryRefuelRepo{operations: map[string]entity.RefuelOperation{}}
+			counters := &memoryCounterRepo{state: entity.CounterState{DeviceID: "pump-1", CurrentValue: stored, Version: 1}}
+			limits := NewLimitsPolicy(&memoryLimitsRepo{limits: &entity.Limits{MaxPricePerLiter: 10000, MaxAmountPaid: 500000}}, DefaultLimits())
+			s := &RefuelOperationService{
+				refuelRepo:     repo,
+				paymentRepo:    &memoryPaymentRepo{},
+				priceService:   NewFuelPriceService(fixedPriceRepo{price: 5499}, nil, limits),
+				counterService: NewCounterStateService(counters, nil),
+				deviceService:  NewDeviceService(activeDeviceRepo{}),
+				transactor:     noTxTransactor{},
+				limits:         limits,
+			}
+
+			operation, err := tt.create(s)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if counters.state.CurrentValue != stored || len(repo.operations) != 0 {
+					t.Fatalf("rejected request changed state: counter %d, operations %d", counters.state.CurrentValue, len(repo.operations))
+				}
+				return
+			}
+
+			if counters.state.CurrentValue != sent || operation.AmountPaid != 109980 || operation.Paid != 109980 {
+				t.Fatalf("counter %d, operation paid %d of %d", counters.state.CurrentValue, operation.Paid, operation.AmountPaid)
+			}
+		})
+	}
+}
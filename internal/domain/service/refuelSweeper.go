@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// Итог прохода очистки зависших операций
+type SweepResult struct {
+	StartedAt  time.Time
+	FinishedAt time.Time
+	PendingTTL time.Duration
+	Cancelled  []int64 // ID отменённых операций
+	Failed     int     // сколько операций не удалось отменить
+	Error      string  // ошибки прохода, по одной на строку (пусто — без ошибок)
+}
+
+// Фоновая отмена операций, зависших в Created дольше ttl (например, терминал отключился посреди заправки)
+type RefuelSweeper struct {
+	refuelService *RefuelOperationService
+	ttl           time.Duration // сколько операция может ждать подтверждения
+	interval      time.Duration // как часто искать зависшие операции
+
+	mu   sync.Mutex
+	last SweepResult
+}
+
+func NewRefuelSweeper(refuelService *RefuelOperationService, ttl, interval time.Duration) *RefuelSweeper {
+	return &RefuelSweeper{
+		refuelService: refuelService,
+		ttl:           ttl,
+		interval:      interval,
+	}
+}
+
+// Запускает очистку сразу и далее по расписанию, пока не отменён ctx
+func (s *RefuelSweeper) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		result := s.Sweep(ctx)
+		if len(result.Cancelled) > 0 {
+			log.Printf("✅ Отменено зависших операций: %d\n", len(result.Cancelled))
+		}
+		if result.Error != "" {
+			log.Printf("⚠️ Ошибка отмены зависших операций: %s\n", result.Error)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Отменяет операции, созданные раньше now-ttl и до сих пор не подтверждённые.
+// Операцию, подтверждённую или отменённую между поиском и отменой, не трогаем
+func (s *RefuelSweeper) Sweep(ctx context.Context) SweepResult {
+	result := SweepResult{
+		StartedAt:  time.Now(),
+		PendingTTL: s.ttl,
+	}
+
+	var errs []error
+	cutoff := result.StartedAt.Add(-s.ttl)
+	expired, err := s.refuelService.GetExpiredOperations(ctx, cutoff)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	reason := fmt.Sprintf("автоотмена: операция не подтверждена за %s", s.ttl)
+	for _, operation := range expired {
+		_, err := s.refuelService.CancelExpired(ctx, operation.ID, cutoff, reason)
+		// Операцию успели подтвердить или отменить — отменять уже нечего
+		if errors.Is(err, ErrInvalidOperationStatus) {
+			continue
+		}
+		if err != nil {
+			result.Failed++
+			errs = append(errs, fmt.Errorf("операция #%d: %w", operation.ID, err))
+			continue
+		}
+
+		result.Cancelled = append(result.Cancelled, operation.ID)
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		result.Error = err.Error()
+	}
+	result.FinishedAt = time.Now()
+
+	s.mu.Lock()
+	s.last = result
+	s.mu.Unlock()
+
+	return result
+}
+
+// Итог последнего прохода (нулевой, если проходов ещё не было)
+func (s *RefuelSweeper) LastResult() SweepResult {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.last
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"fuelStation/internal/domain/entity"
+	"fuelStation/internal/domain/interfaces"
+	"strings"
+	"time"
+)
+
+// Способы выдачи возврата
+const (
+	RefundMethodCash = "Cash"
+	RefundMethodCard = "Card"
+)
+
+// Возвраты клиентам по частично выполненным и отменённым операциям
+type RefundService struct {
+	repo       interfaces.RefundRepository
+	refuelRepo interfaces.RefuelOperationsRepo
+}
+
+func NewRefundService(repo interfaces.RefundRepository, refuelRepo interfaces.RefuelOperationsRepo) *RefundService {
+	return &RefundService{
+		repo:       repo,
+		refuelRepo: refuelRepo,
+	}
+}
+
+// Выдать клиенту amount по операции operationID. Вместе с уже выданным
+// сумма не может превышать RefundDue операции; возврат положен только по
+// частично выполненной или отменённой операции
+func (s *RefundService) Issue(ctx context.Context, operationID string, amount entity.Money, method, issuedBy string) (entity.Refund, error) {
+	method, ok := refundMethod(method)
+	issuedBy = strings.TrimSpace(issuedBy)
+	if !ok || amount <= 0 || issuedBy == "" {
+		return entity.Refund{}, ErrInvalidRefund
+	}
+
+	operation, err := s.refuelRepo.GetByID(ctx, operationID)
+	if err != nil {
+		return entity.Refund{}, err
+	}
+
+	// Быстрая проверка; окончательная — в репозитории под блокировкой строки операции
+	if !refundable(operation.Status) {
+		return entity.Refund{}, ErrInvalidOperationStatus
+	}
+	if amount > operation.RefundDue-operation.Refunded {
+		return entity.Refund{}, ErrRefundExceedsDue
+	}
+
+	refund := entity.Refund{
+		OperationID: operation.ID,
+		Amount:      amount,
+		Method:      method,
+		IssuedBy:    issuedBy,
+		IssuedAt:    time.Now(),
+	}
+
+	if err := s.repo.Create(ctx, &refund); err != nil {
+		return entity.Refund{}, err
+	}
+
+	return refund, nil
+}
+
+// Возвраты по операции
+func (s *RefundService) List(ctx context.Context, operationID string) ([]entity.Refund, error) {
+	operation, err := s.refuelRepo.GetByID(ctx, operationID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.ListByOperation(ctx, operation.ID)
+}
+
+// Операции, по которым клиенту ещё не всё вернули (пустой deviceID — на всех колонках)
+func (s *RefundService) Outstanding(ctx context.Context, deviceID string) ([]entity.RefuelOperation, error) {
+	filter := interfaces.RefuelFilter{
+		Statuses:          RefundableStatuses(),
+		RefundOutstanding: true,
+	}
+	if deviceID != "" {
+		filter.DeviceID = &deviceID
+	}
+
+	return s.refuelRepo.Find(ctx, filter)
+}
+
+// Статусы операций, по которым может быть положен возврат: за недолитое топливо
+// или вся оплата отменённой операции
+func RefundableStatuses() []string {
+	return []string{RefuelStatusPartiallyFulfilled, RefuelStatusCancelled}
+}
+
+func refundable(status string) bool {
+	return status == RefuelStatusPartiallyFulfilled || status == RefuelStatusCancelled
+}
+
+// Способ возврата без учёта регистра: cash, CASH и Cash — одно и то же
+func refundMethod(method string) (string, bool) {
+	for _, m := range []string{RefundMethodCash, RefundMethodCard} {
+		if strings.EqualFold(strings.TrimSpace(method), m) {
+			return m, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,220 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"fuelStation/internal/domain/entity"
+	"time"
+)
+
+// Версия формата сохранённого Z-отчёта. Формат не зависит от имён полей ShiftReport:
+// при несовместимом изменении заводится новая версия, а старые отчёты читаются по своей
+const zReportVersion = 1
+
+// Z-отчёт в том виде, в котором он хранится в смене (без самой смены — она хранится отдельно).
+// Суммы в копейках, объёмы и счётчики в десятых литра
+type zReport struct {
+	Version            int                    `json:"version"`
+	To                 time.Time              `json:"to"`
+	Statistics         zReportStatistics      `json:"statistics"`
+	Devices            []zReportDevice        `json:"devices"`
+	ByPaymentMethod    []zReportPaymentMethod `json:"by_payment_method"`
+	CounterDelta       int64                  `json:"counter_delta"`
+	SoldVolume         int64                  `json:"sold_volume"`
+	Discrepancy        int64                  `json:"discrepancy"`
+	ExpectedCashKop    int64                  `json:"expected_cash_kop"`
+	CashDiscrepancyKop *int64                 `json:"cash_discrepancy_kop,omitempty"`
+}
+
+type zReportStatistics struct {
+	TotalOperations  int64                  `json:"total_operations"`
+	TotalRevenueKop  int64                  `json:"total_revenue_kop"`
+	TotalRefundedKop int64                  `json:"total_refunded_kop"`
+	OutstandingKop   int64                  `json:"outstanding_refunds_kop"`
+	TotalVolume      int64                  `json:"total_volume"`
+	AverageVolume    int64                  `json:"average_volume"`
+	AverageAmountKop int64                  `json:"average_amount_kop"`
+	ConfirmedCount   int64                  `json:"confirmed_count"`
+	PartialCount     int64                  `json:"partially_fulfilled_count"`
+	CancelledCount   int64                  `json:"cancelled_count"`
+	PendingCount     int64                  `json:"pending_count"`
+	StartDate        time.Time              `json:"start_date"`
+	EndDate          time.Time              `json:"end_date"`
+	ByGrade          []zReportGrade         `json:"by_grade"`
+	ByPaymentMethod  []zReportPaymentMethod `json:"by_payment_method"`
+	UnallocatedKop   int64                  `json:"unallocated_kop"`
+}
+
+type zReportGrade struct {
+	Grade           string `json:"grade"`
+	ConfirmedCount  int64  `json:"confirmed_count"`
+	TotalRevenueKop int64  `json:"total_revenue_kop"`
+	TotalVolume     int64  `json:"total_volume"`
+}
+
+type zReportPaymentMethod struct {
+	Method       string `json:"method"`
+	PaymentCount int64  `json:"payment_count"`
+	PaidKop      int64  `json:"paid_kop"`
+	RefundedKop  int64  `json:"refunded_kop"`
+	NetKop       int64  `json:"net_kop"`
+}
+
+type zReportDevice struct {
+	DeviceID     string `json:"device_id"`
+	CounterStart int64  `json:"counter_start"`
+	CounterEnd   int64  `json:"counter_end"`
+	CounterDelta int64  `json:"counter_delta"`
+	SoldVolume   int64  `json:"sold_volume"`
+	Discrepancy  int64  `json:"discrepancy"`
+}
+
+// Z-отчёт для сохранения при закрытии смены
+func encodeZReport(r ShiftReport) ([]byte, error) {
+	z := zReport{
+		Version:            zReportVersion,
+		To:                 r.To,
+		Statistics:         toZReportStatistics(r.Statistics),
+		Devices:            make([]zReportDevice, 0, len(r.Devices)),
+		ByPaymentMethod:    toZReportPaymentMethods(r.ByPaymentMethod),
+		CounterDelta:       int64(r.CounterDelta),
+		SoldVolume:         int64(r.SoldLiters),
+		Discrepancy:        int64(r.Discrepancy),
+		ExpectedCashKop:    int64(r.ExpectedCash),
+		CashDiscrepancyKop: (*int64)(r.CashDiscrepancy),
+	}
+	for _, d := range r.Devices {
+		z.Devices = append(z.Devices, zReportDevice{
+			DeviceID:     d.DeviceID,
+			CounterStart: d.CounterStart,
+			CounterEnd:   d.CounterEnd,
+			CounterDelta: int64(d.CounterDelta),
+			SoldVolume:   int64(d.SoldLiters),
+			Discrepancy:  int64(d.Discrepancy),
+		})
+	}
+
+	return json.Marshal(z)
+}
+
+// Сохранённый Z-отчёт смены shift
+func decodeZReport(data []byte, shift entity.Shift) (ShiftReport, error) {
+	var z zReport
+	if err := json.Unmarshal(data, &z); err != nil {
+		return ShiftReport{}, err
+	}
+	if z.Version != zReportVersion {
+		return ShiftReport{}, fmt.Errorf("неизвестная версия Z-отчёта %d", z.Version)
+	}
+
+	r := ShiftReport{
+		Shift:           shift,
+		To:              z.To,
+		Statistics:      fromZReportStatistics(z.Statistics),
+		ByPaymentMethod: fromZReportPaymentMethods(z.ByPaymentMethod),
+		CounterDelta:    entity.Volume(z.CounterDelta),
+		SoldLiters:      entity.Volume(z.SoldVolume),
+		Discrepancy:     entity.Volume(z.Discrepancy),
+		ExpectedCash:    entity.Money(z.ExpectedCashKop),
+		CashDiscrepancy: (*entity.Money)(z.CashDiscrepancyKop),
+	}
+	for _, d := range z.Devices {
+		r.Devices = append(r.Devices, ShiftDeviceReport{
+			DeviceID:     d.DeviceID,
+			CounterStart: d.CounterStart,
+			CounterEnd:   d.CounterEnd,
+			CounterDelta: entity.Volume(d.CounterDelta),
+			SoldLiters:   entity.Volume(d.SoldVolume),
+			Discrepancy:  entity.Volume(d.Discrepancy),
+		})
+	}
+
+	return r, nil
+}
+
+func toZReportStatistics(s RefuelStatistics) zReportStatistics {
+	z := zReportStatistics{
+		TotalOperations:  s.TotalOperations,
+		TotalRevenueKop:  int64(s.TotalRevenue),
+		TotalRefundedKop: int64(s.TotalRefunded),
+		OutstandingKop:   int64(s.OutstandingRefunds),
+		TotalVolume:      int64(s.TotalLiters),
+		AverageVolume:    int64(s.AverageLiters),
+		AverageAmountKop: int64(s.AverageAmount),
+		ConfirmedCount:   s.ConfirmedCount,
+		PartialCount:     s.PartialCount,
+		CancelledCount:   s.CancelledCount,
+		PendingCount:     s.PendingCount,
+		StartDate:        s.StartDate,
+		EndDate:          s.EndDate,
+		ByGrade:          make([]zReportGrade, 0, len(s.ByGrade)),
+		ByPaymentMethod:  toZReportPaymentMethods(s.ByPaymentMethod),
+		UnallocatedKop:   int64(s.Unallocated),
+	}
+	for _, g := range s.ByGrade {
+		z.ByGrade = append(z.ByGrade, zReportGrade{
+			Grade:           g.GradeCode,
+			ConfirmedCount:  g.ConfirmedCount,
+			TotalRevenueKop: int64(g.TotalRevenue),
+			TotalVolume:     int64(g.TotalLiters),
+		})
+	}
+	return z
+}
+
+func fromZReportStatistics(z zReportStatistics) RefuelStatistics {
+	s := RefuelStatistics{
+		TotalOperations:    z.TotalOperations,
+		TotalRevenue:       entity.Money(z.TotalRevenueKop),
+		TotalRefunded:      entity.Money(z.TotalRefundedKop),
+		OutstandingRefunds: entity.Money(z.OutstandingKop),
+		TotalLiters:        entity.Volume(z.TotalVolume),
+		AverageLiters:      entity.Volume(z.AverageVolume),
+		AverageAmount:      entity.Money(z.AverageAmountKop),
+		ConfirmedCount:     z.ConfirmedCount,
+		PartialCount:       z.PartialCount,
+		CancelledCount:     z.CancelledCount,
+		PendingCount:       z.PendingCount,
+		StartDate:          z.StartDate,
+		EndDate:            z.EndDate,
+		ByPaymentMethod:    fromZReportPaymentMethods(z.ByPaymentMethod),
+		Unallocated:        entity.Money(z.UnallocatedKop),
+	}
+	for _, g := range z.ByGrade {
+		s.ByGrade = append(s.ByGrade, GradeStatistics{
+			GradeCode:      g.Grade,
+			ConfirmedCount: g.ConfirmedCount,
+			TotalRevenue:   entity.Money(g.TotalRevenueKop),
+			TotalLiters:    entity.Volume(g.TotalVolume),
+		})
+	}
+	return s
+}
+
+func toZReportPaymentMethods(methods []PaymentMethodStatistics) []zReportPaymentMethod {
+	z := make([]zReportPaymentMethod, 0, len(methods))
+	for _, m := range methods {
+		z = append(z, zReportPaymentMethod{
+			Method:       m.Method,
+			PaymentCount: m.PaymentCount,
+			PaidKop:      int64(m.Paid),
+			RefundedKop:  int64(m.Refunded),
+			NetKop:       int64(m.Net),
+		})
+	}
+	return z
+}
+
+func fromZReportPaymentMethods(z []zReportPaymentMethod) []PaymentMethodStatistics {
+	var methods []PaymentMethodStatistics
+	for _, m := range z {
+		methods = append(methods, PaymentMethodStatistics{
+			Method:       m.Method,
+			PaymentCount: m.PaymentCount,
+			Paid:         entity.Money(m.PaidKop),
+			Refunded:     entity.Money(m.RefundedKop),
+			Net:          entity.Money(m.NetKop),
+		})
+	}
+	return methods
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"fuelStation/internal/domain/entity"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestZReportRoundTrip(t *testing.T) {
+	closedAt := time.Date(2026, 3, 1, 20, 0, 0, 0, time.UTC)
+	cashDiff := entity.Money(-150)
+	report := ShiftReport{
+		Shift: entity.Shift{ID: 7, Operator: "Иванов", ClosedAt: &closedAt},
+		To:    closedAt,
+		Statistics: RefuelStatistics{
+			TotalOperations: 3,
+			TotalRevenue:    199064,
+			TotalLiters:     362,
+			ConfirmedCount:  2,
+			CancelledCount:  1,
+			StartDate:       closedAt.Add(-12 * time.Hour),
+			EndDate:         closedAt,
+			ByGrade:         []GradeStatistics{{GradeCode: "AI95", ConfirmedCount: 2, TotalRevenue: 199064, TotalLiters: 362}},
+			ByPaymentMethod: []PaymentMethodStatistics{{Method: PaymentMethodCard, PaymentCount: 2, Paid: 199064, Net: 199064}},
+		},
+		Devices:         []ShiftDeviceReport{{DeviceID: "pump-1", CounterStart: 1000, CounterEnd: 1372, CounterDelta: 372, SoldLiters: 362, Discrepancy: 10}},
+		ByPaymentMethod: []PaymentMethodStatistics{{Method: PaymentMethodCash, PaymentCount: 1, Paid: 50000, Refunded: 550, Net: 49450}},
+		CounterDelta:    372,
+		SoldLiters:      362,
+		Discrepancy:     10,
+		ExpectedCash:    149450,
+		CashDiscrepancy: &cashDiff,
+	}
+
+	data, err := encodeZReport(report)
+	if err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	got, err := decodeZReport(data, report.Shift)
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if !reflect.DeepEqual(got, report) {
+		t.Fatalf("round trip mismatch:\n got %+v\nwant %+v", got, report)
+	}
+}
+
+// Отчёты, сохранённые в версии 1, должны читаться и дальше
+func TestZReportDecodeVersion1(t *testing.T) {
+	data := []byte(`{
+		"version": 1,
+		"to": "2026-03-01T20:00:00Z",
+		"statistics": {"total_operations": 2, "total_revenue_kop": 100000, "total_volume": 181, "by_grade": [{"grade": "AI95", "total_volume": 181}]},
+		"devices": [{"device_id": "pump-1", "counter_start": 1000, "counter_end": 1181, "counter_delta": 181, "sold_volume": 181}],
+		"by_payment_method": [{"method": "Cash", "payment_count": 1, "paid_kop": 100000, "net_kop": 100000}],
+		"counter_delta": 181,
+		"sold_volume": 181,
+		"expected_cash_kop": 150000,
+		"cash_discrepancy_kop": 0
+	}`)
+
+	got, err := decodeZReport(data, entity.Shift{ID: 1})
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if got.Statistics.TotalRevenue != 100000 || got.Statistics.ByGrade[0].TotalLiters != 181 ||
+		got.Devices[0].SoldLiters != 181 || got.ByPaymentMethod[0].Net != 100000 ||
+		got.ExpectedCash != 150000 || got.CashDiscrepancy == nil || *got.CashDiscrepancy != 0 {
+		t.Fatalf("decoded report = %+v", got)
+	}
+
+	if _, err := decodeZReport([]byte(`{"version": 2}`), entity.Shift{ID: 1}); err == nil {
+		t.Fatal("unknown version decoded without error")
+	}
+}
@@ -0,0 +1,287 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"fuelStation/internal/domain/entity"
+	"fuelStation/internal/domain/interfaces"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Смены операторов и отчёты по ним
+type ShiftService struct {
+	repo           interfaces.ShiftRepository
+	paymentRepo    interfaces.PaymentRepository
+	refundRepo     interfaces.RefundRepository
+	refuelService  *RefuelOperationService
+	counterService *CounterStateService
+	deviceService  *DeviceService
+}
+
+func NewShiftService(repo interfaces.ShiftRepository, paymentRepo interfaces.PaymentRepository, refundRepo interfaces.RefundRepository, refuelService *RefuelOperationService, counterService *CounterStateService, deviceService *DeviceService) *ShiftService {
+	return &ShiftService{
+		repo:           repo,
+		paymentRepo:    paymentRepo,
+		refundRepo:     refundRepo,
+		refuelService:  refuelService,
+		counterService: counterService,
+		deviceService:  deviceService,
+	}
+}
+
+// Отчёт по смене: итог на закрытии (Z-отчёт, сохраняется и дальше не меняется)
+// или промежуточный для открытой смены
+type ShiftReport struct {
+	Shift      entity.Shift
+	To         time.Time        // конец периода: закрытие смены или момент запроса
+	Statistics RefuelStatistics // операции, созданные за смену: литры, выручка и отмены
+	Devices    []ShiftDeviceReport
+
+	// Деньги, прошедшие через кассу за смену: платежи по времени приёма и возвраты
+	// по времени выдачи, в том числе по операциям прошлых смен
+	ByPaymentMethod []PaymentMethodStatistics
+
+	// Итого по колонкам. SoldLiters считается по времени подтверждения и отмены, как и сдвигается
+	// счётчик. Discrepancy = CounterDelta - SoldLiters; не ноль — топливо ушло мимо операций
+	// или счётчик выставляли вручную
+	CounterDelta entity.Volume
+	SoldLiters   entity.Volume
+	Discrepancy  entity.Volume
+
+	ExpectedCash    entity.Money  // наличные на открытии + наличные платежи - наличные возвраты за смену
+	CashDiscrepancy *entity.Money // пересчитанные на закрытии наличные минус ExpectedCash (для закрытой смены)
+}
+
+// Сверка счётчика колонки с проданным за смену
+type ShiftDeviceReport struct {
+	DeviceID     string
+	CounterStart int64
+	CounterEnd   int64
+	CounterDelta entity.Volume // сдвиг счётчика за смену
+	SoldLiters   entity.Volume // отпущено по операциям, подтверждённым за смену, за вычетом отменённых за смену
+	Discrepancy  entity.Volume // CounterDelta - SoldLiters
+}
+
+// Открыть смену оператора operator с наличными openingCash в кассе.
+// Запоминает текущие показания счётчиков всех колонок
+func (s *ShiftService) Open(ctx context.Context, operator string, openingCash entity.Money) (entity.Shift, error) {
+	operator = strings.TrimSpace(operator)
+	if operator == "" || openingCash < 0 {
+		return entity.Shift{}, ErrInvalidShift
+	}
+
+	devices, err := s.deviceService.List(ctx)
+	if err != nil {
+		return entity.Shift{}, err
+	}
+
+	shift := entity.Shift{
+		Operator:    operator,
+		OpenedAt:    time.Now(),
+		OpeningCash: openingCash,
+	}
+
+	for _, device := range devices {
+		counter, err := s.counterService.GetCurrent(ctx, device.ID)
+		// Счётчик колонки ещё ни разу не выставлялся — сверять нечего
+		if errors.Is(err, ErrNotFoundCounter) {
+			continue
+		}
+		if err != nil {
+			return entity.Shift{}, err
+		}
+
+		shift.Counters = append(shift.Counters, entity.ShiftCounter{
+			DeviceID:   device.ID,
+			StartValue: counter.CurrentValue,
+		})
+	}
+
+	if err := s.repo.Create(ctx, &shift); err != nil {
+		return entity.Shift{}, err
+	}
+
+	return shift, nil
+}
+
+// Закрыть открытую смену с пересчитанными наличными closingCash и получить Z-отчёт.
+// Отчёт сохраняется вместе с закрытием: поздние отмены и возвраты его уже не меняют
+func (s *ShiftService) Close(ctx context.Context, closingCash entity.Money) (ShiftReport, error) {
+	if closingCash < 0 {
+		return ShiftReport{}, ErrInvalidShift
+	}
+
+	shift, err := s.repo.GetOpen(ctx)
+	if err != nil {
+		return ShiftReport{}, err
+	}
+
+	if err := s.readEndCounters(ctx, &shift); err != nil {
+		return ShiftReport{}, err
+	}
+
+	closedAt := time.Now()
+	shift.ClosedAt = &closedAt
+	shift.ClosingCash = &closingCash
+
+	report, err := s.report(ctx, shift)
+	if err != nil {
+		return ShiftReport{}, err
+	}
+
+	snapshot, err := encodeZReport(report)
+	if err != nil {
+		return ShiftReport{}, fmt.Errorf("ошибка сохранения Z-отчёта: %w", err)
+	}
+
+	if err := s.repo.Close(ctx, shift, snapshot); err != nil {
+		return ShiftReport{}, err
+	}
+
+	return report, nil
+}
+
+// Промежуточный отчёт по открытой смене (ErrNoOpenShift, если её нет)
+func (s *ShiftService) Current(ctx context.Context) (ShiftReport, error) {
+	shift, err := s.repo.GetOpen(ctx)
+	if err != nil {
+		return ShiftReport{}, err
+	}
+
+	if err := s.readEndCounters(ctx, &shift); err != nil {
+		return ShiftReport{}, err
+	}
+
+	return s.report(ctx, shift)
+}
+
+// Отчёт по смене id: для закрытой — сохранённый Z-отчёт, для открытой — на текущий момент
+func (s *ShiftService) Report(ctx context.Context, id string) (ShiftReport, error) {
+	shift, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return ShiftReport{}, err
+	}
+
+	if shift.ClosedAt == nil {
+		if err := s.readEndCounters(ctx, &shift); err != nil {
+			return ShiftReport{}, err
+		}
+		return s.report(ctx, shift)
+	}
+
+	snapshot, err := s.repo.GetReport(ctx, shift.ID)
+	if err != nil {
+		return ShiftReport{}, err
+	}
+	// Смены, закрытые до сохранения Z-отчётов, пересчитываются
+	if snapshot == nil {
+		return s.report(ctx, shift)
+	}
+
+	report, err := decodeZReport(snapshot, shift)
+	if err != nil {
+		return ShiftReport{}, fmt.Errorf("ошибка чтения Z-отчёта смены %d: %w", shift.ID, err)
+	}
+
+	return report, nil
+}
+
+// Смены, открытые за промежуток (пустой operator — все операторы)
+func (s *ShiftService) List(ctx context.Context, from, to time.Time, operator string) ([]entity.Shift, error) {
+	filter := interfaces.ShiftFilter{
+		DateFrom: &from,
+		DateTo:   &to,
+	}
+	if operator = strings.TrimSpace(operator); operator != "" {
+		filter.Operator = &operator
+	}
+
+	return s.repo.Find(ctx, filter)
+}
+
+// Проставляет счётчикам смены текущие показания как конечные
+func (s *ShiftService) readEndCounters(ctx context.Context, shift *entity.Shift) error {
+	for i, c := range shift.Counters {
+		counter, err := s.counterService.GetCurrent(ctx, c.DeviceID)
+		if err != nil {
+			return err
+		}
+		end := counter.CurrentValue
+		shift.Counters[i].EndValue = &end
+	}
+	return nil
+}
+
+// Собирает отчёт по смене с уже проставленными конечными показаниями счётчиков.
+// Колонки, зарегистрированные после открытия смены, в сверку счётчиков не входят
+func (s *ShiftService) report(ctx context.Context, shift entity.Shift) (ShiftReport, error) {
+	to := time.Now()
+	if shift.ClosedAt != nil {
+		to = *shift.ClosedAt
+	}
+
+	stats, err := s.refuelService.GetStatistics(ctx, shift.OpenedAt, to)
+	if err != nil {
+		return ShiftReport{}, err
+	}
+
+	sold, err := s.refuelService.dispensedByDevice(ctx, shift.OpenedAt, to)
+	if err != nil {
+		return ShiftReport{}, err
+	}
+
+	report := ShiftReport{
+		Shift:        shift,
+		To:           to,
+		Statistics:   stats,
+		ExpectedCash: shift.OpeningCash,
+	}
+
+	for _, c := range shift.Counters {
+		end := c.StartValue
+		if c.EndValue != nil {
+			end = *c.EndValue
+		}
+
+		device := ShiftDeviceReport{
+			DeviceID:     c.DeviceID,
+			CounterStart: c.StartValue,
+			CounterEnd:   end,
+			CounterDelta: entity.Volume(end - c.StartValue),
+			SoldLiters:   sold[c.DeviceID],
+		}
+		device.Discrepancy = device.CounterDelta - device.SoldLiters
+
+		report.Devices = append(report.Devices, device)
+		report.CounterDelta += device.CounterDelta
+		report.SoldLiters += device.SoldLiters
+	}
+	sort.Slice(report.Devices, func(i, j int) bool { return report.Devices[i].DeviceID < report.Devices[j].DeviceID })
+	report.Discrepancy = report.CounterDelta - report.SoldLiters
+
+	// Деньги относим к смене по моменту движения, а не по времени создания операции
+	payments, err := s.paymentRepo.ListByPeriod(ctx, shift.OpenedAt, to)
+	if err != nil {
+		return ShiftReport{}, err
+	}
+	refunds, err := s.refundRepo.ListByPeriod(ctx, shift.OpenedAt, to)
+	if err != nil {
+		return ShiftReport{}, err
+	}
+	report.ByPaymentMethod = statisticsByPaymentMethod(payments, refunds)
+
+	for _, m := range report.ByPaymentMethod {
+		if m.Method == PaymentMethodCash {
+			report.ExpectedCash += m.Net
+		}
+	}
+	if shift.ClosingCash != nil {
+		diff := *shift.ClosingCash - report.ExpectedCash
+		report.CashDiscrepancy = &diff
+	}
+
+	return report, nil
+}
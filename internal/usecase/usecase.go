@@ -14,9 +14,21 @@ type UseCase struct {
 
 	priceService *service.FuelPriceService
 	priceRepo    interfaces.FuelPriceRepository
+	gradeService *service.FuelGradeService
+
+	scheduleService *service.PriceScheduleService
 
 	counterService *service.CounterStateService
 	counterRepo    interfaces.CounterRepository
+
+	deviceService *service.DeviceService
+
+	refuelSweeper  *service.RefuelSweeper
+	refundService  *service.RefundService
+	paymentService *service.PaymentService
+	shiftService   *service.ShiftService
+
+	limits *service.LimitsPolicy
 }
 
 func NewUsecase(
@@ -24,8 +36,16 @@ func NewUsecase(
 	refuelRepo interfaces.RefuelOperationsRepo,
 	priceService *service.FuelPriceService,
 	priceRepo interfaces.FuelPriceRepository,
+	gradeService *service.FuelGradeService,
+	scheduleService *service.PriceScheduleService,
 	counterService *service.CounterStateService,
 	counterRepo interfaces.CounterRepository,
+	deviceService *service.DeviceService,
+	refuelSweeper *service.RefuelSweeper,
+	refundService *service.RefundService,
+	paymentService *service.PaymentService,
+	shiftService *service.ShiftService,
+	limits *service.LimitsPolicy,
 
 ) *UseCase {
 	return &UseCase{
@@ -34,15 +54,37 @@ func NewUsecase(
 
 		priceService: priceService,
 		priceRepo:    priceRepo,
+		gradeService: gradeService,
+
+		scheduleService: scheduleService,
 
 		counterService: counterService,
 		counterRepo:    counterRepo,
+
+		deviceService: deviceService,
+
+		refuelSweeper:  refuelSweeper,
+		refundService:  refundService,
+		paymentService: paymentService,
+		shiftService:   shiftService,
+
+		limits: limits,
 	}
 }
 
-// Создание заправки
-func (u *UseCase) CreateRefuel(ctx context.Context, amountPaid float64, counterBeforeRefill int) (entity.RefuelOperation, error) {
-	return u.refuelService.CreateRefuel(ctx, amountPaid, counterBeforeRefill)
+// Создание заправки марки grade на колонке deviceID (payments — разбивка оплаты, может быть пустой)
+func (u *UseCase) CreateRefuel(ctx context.Context, deviceID, grade string, amountPaid entity.Money, counterBeforeRefill int, idempotencyKey string, payments []entity.Payment) (entity.RefuelOperation, error) {
+	return u.refuelService.CreateRefuel(ctx, deviceID, grade, amountPaid, counterBeforeRefill, idempotencyKey, payments)
+}
+
+// Создание заправки на заданный объём (десятые литра)
+func (u *UseCase) CreateRefuelByVolume(ctx context.Context, deviceID, grade string, volume entity.Volume, counterBeforeRefill int, idempotencyKey string, payments []entity.Payment) (entity.RefuelOperation, error) {
+	return u.refuelService.CreateRefuelByVolume(ctx, deviceID, grade, volume, counterBeforeRefill, idempotencyKey, payments)
+}
+
+// Создание заправки до полного бака; подтверждается только по показанию счётчика
+func (u *UseCase) CreateFillUp(ctx context.Context, deviceID, grade string, counterBeforeRefill int, idempotencyKey string) (entity.RefuelOperation, error) {
+	return u.refuelService.CreateFillUp(ctx, deviceID, grade, counterBeforeRefill, idempotencyKey)
 }
 
 // Подтверждение заправки
@@ -50,41 +92,123 @@ func (u *UseCase) ConfirmRefuel(ctx context.Context, id string) (entity.RefuelOp
 	return u.refuelService.ConfirmRefuel(ctx, id)
 }
 
+// Подтверждение заправки по фактическому показанию счётчика
+func (u *UseCase) ConfirmRefuelWithCounter(ctx context.Context, id string, counterAfter int) (entity.RefuelOperation, error) {
+	return u.refuelService.ConfirmRefuelWithCounter(ctx, id, counterAfter)
+}
+
 // Отмена заправки
 func (u *UseCase) CancelRefuel(ctx context.Context, id, reason string) (entity.RefuelOperation, error) {
 	return u.refuelService.CancelRefuel(ctx, id, reason)
 }
 
-// Проверка наличия незавершенных заправок
-func (u *UseCase) HasPendingOperations(ctx context.Context) (bool, error) {
-	return u.refuelService.HasPendingOperations(ctx)
+// Проверка наличия незавершенных заправок на колонке (пустой deviceID — на всех)
+func (u *UseCase) HasPendingOperations(ctx context.Context, deviceID string) (bool, error) {
+	return u.refuelService.HasPendingOperations(ctx, deviceID)
 }
 
-// Показать незавершенные заправки
-func (u *UseCase) GetPendingOperations(ctx context.Context) ([]entity.RefuelOperation, error) {
-	return u.refuelService.GetPendingOperations(ctx)
+// Показать незавершенные заправки на колонке (пустой deviceID — на всех)
+func (u *UseCase) GetPendingOperations(ctx context.Context, deviceID string) ([]entity.RefuelOperation, error) {
+	return u.refuelService.GetPendingOperations(ctx, deviceID)
 }
 
-// Получить историю заправок за период
-func (u *UseCase) GetRefuelHistory(ctx context.Context, from, to time.Time, status string) ([]entity.RefuelOperation, error) {
+// Отменить зависшие операции сейчас, не дожидаясь фоновой очистки
+func (u *UseCase) ExpirePendingOperations(ctx context.Context) service.SweepResult {
+	return u.refuelSweeper.Sweep(ctx)
+}
+
+// Итог последней очистки зависших операций (для мониторинга)
+func (u *UseCase) GetLastSweep() service.SweepResult {
+	return u.refuelSweeper.LastResult()
+}
+
+// Выдать клиенту возврат по операции
+func (u *UseCase) IssueRefund(ctx context.Context, operationID string, amount entity.Money, method, issuedBy string) (entity.Refund, error) {
+	return u.refundService.Issue(ctx, operationID, amount, method, issuedBy)
+}
+
+// Получить возвраты по операции
+func (u *UseCase) GetRefunds(ctx context.Context, operationID string) ([]entity.Refund, error) {
+	return u.refundService.List(ctx, operationID)
+}
+
+// Получить операции с ещё не выданным возвратом (пустой deviceID — на всех колонках)
+func (u *UseCase) GetOutstandingRefunds(ctx context.Context, deviceID string) ([]entity.RefuelOperation, error) {
+	return u.refundService.Outstanding(ctx, deviceID)
+}
+
+// Принять платёж по операции
+func (u *UseCase) AddPayment(ctx context.Context, operationID string, payment entity.Payment) (entity.Payment, error) {
+	return u.paymentService.Add(ctx, operationID, payment)
+}
+
+// Получить платежи по операции
+func (u *UseCase) GetPayments(ctx context.Context, operationID string) ([]entity.Payment, error) {
+	return u.paymentService.List(ctx, operationID)
+}
+
+// Открыть смену оператора
+func (u *UseCase) OpenShift(ctx context.Context, operator string, openingCash entity.Money) (entity.Shift, error) {
+	return u.shiftService.Open(ctx, operator, openingCash)
+}
+
+// Закрыть открытую смену и получить Z-отчёт
+func (u *UseCase) CloseShift(ctx context.Context, closingCash entity.Money) (service.ShiftReport, error) {
+	return u.shiftService.Close(ctx, closingCash)
+}
+
+// Получить отчёт по открытой смене на текущий момент
+func (u *UseCase) GetCurrentShift(ctx context.Context) (service.ShiftReport, error) {
+	return u.shiftService.Current(ctx)
+}
+
+// Получить отчёт по смене
+func (u *UseCase) GetShiftReport(ctx context.Context, id string) (service.ShiftReport, error) {
+	return u.shiftService.Report(ctx, id)
+}
+
+// Получить смены, открытые за период (пустой operator — все операторы)
+func (u *UseCase) GetShifts(ctx context.Context, from, to time.Time, operator string) ([]entity.Shift, error) {
+	return u.shiftService.List(ctx, from, to, operator)
+}
+
+// Получить историю заправок за период (пустые deviceID, status и grade — без фильтра)
+func (u *UseCase) GetRefuelHistory(ctx context.Context, from, to time.Time, deviceID, status, grade string) ([]entity.RefuelOperation, error) {
 	filter := interfaces.RefuelFilter{
 		DateFrom: &from,
 		DateTo:   &to,
 		Status:   &status,
 	}
+	if deviceID != "" {
+		filter.DeviceID = &deviceID
+	}
+	if grade != "" {
+		grade = service.NormalizeGradeCode(grade)
+		filter.Grade = &grade
+	}
 	return u.refuelRepo.Find(ctx, filter)
 }
 
 // Получить заработанные деньги за период
-func (u *UseCase) GetTotalRevenue(ctx context.Context, from, to time.Time) (float64, error) {
+func (u *UseCase) GetTotalRevenue(ctx context.Context, from, to time.Time) (entity.Money, error) {
 	return u.refuelService.GetTotalRevenue(ctx, from, to)
 }
 
 // Получить потраченные литры за период
-func (u *UseCase) GetTotalLiters(ctx context.Context, from, to time.Time) (float64, error) {
+func (u *UseCase) GetTotalLiters(ctx context.Context, from, to time.Time) (entity.Volume, error) {
 	return u.refuelService.GetTotalLiters(ctx, from, to)
 }
 
+// Получить заработанные деньги за период по маркам
+func (u *UseCase) GetTotalRevenueByGrade(ctx context.Context, from, to time.Time) (map[string]entity.Money, error) {
+	return u.refuelService.GetTotalRevenueByGrade(ctx, from, to)
+}
+
+// Получить потраченные литры за период по маркам
+func (u *UseCase) GetTotalLitersByGrade(ctx context.Context, from, to time.Time) (map[string]entity.Volume, error) {
+	return u.refuelService.GetTotalLitersByGrade(ctx, from, to)
+}
+
 // Получить статистику за период
 func (u *UseCase) GetStatistics(ctx context.Context, from, to time.Time) (service.RefuelStatistics, error) {
 	return u.refuelService.GetStatistics(ctx, from, to)
@@ -104,46 +228,120 @@ func (u *UseCase) GetAllRefuel(ctx context.Context, from, to time.Time) ([]entit
 	return u.refuelRepo.Find(ctx, filter)
 }
 
-// Получить активную цену
-func (u *UseCase) GetPricePerLiter(ctx context.Context) (entity.FuelPrice, error) {
-	return u.priceRepo.GetActive(ctx)
+// Получить активную цену марки
+func (u *UseCase) GetPricePerLiter(ctx context.Context, grade string) (entity.FuelPrice, error) {
+	return u.priceService.GetActive(ctx, grade)
+}
+
+// Получить активные цены всех марок
+func (u *UseCase) GetActivePrices(ctx context.Context) ([]entity.FuelPrice, error) {
+	return u.priceService.ListActive(ctx)
 }
 
-// Поменять цену за литр на новую
-func (u *UseCase) ChangePricePerLiter(ctx context.Context, newPrice float64) (entity.FuelPrice, error) {
-	return u.priceService.ChangePrice(ctx, newPrice)
+// Поменять цену за литр марки на новую
+func (u *UseCase) ChangePricePerLiter(ctx context.Context, grade string, newPrice entity.Money) (entity.FuelPrice, error) {
+	return u.priceService.ChangePrice(ctx, grade, newPrice)
 }
 
-// Установить цену за литр впервые
-func (u *UseCase) InitPricePerLiter(ctx context.Context, newPrice float64) (entity.FuelPrice, error) {
-	return u.priceService.InitPrice(ctx, newPrice)
+// Установить цену за литр марки впервые
+func (u *UseCase) InitPricePerLiter(ctx context.Context, grade string, newPrice entity.Money) (entity.FuelPrice, error) {
+	return u.priceService.InitPrice(ctx, grade, newPrice)
 }
 
-// Найти изменения цен за промежуток
-func (u *UseCase) GetPriceHistory(ctx context.Context, from, to time.Time) ([]entity.FuelPrice, error) {
+// Найти изменения цен за промежуток (пустой grade — все марки)
+func (u *UseCase) GetPriceHistory(ctx context.Context, from, to time.Time, grade string) ([]entity.FuelPrice, error) {
 	filter := interfaces.FuelPriceFilter{
 		DateFrom: &from,
 		DateTo:   &to,
 	}
+	if grade != "" {
+		grade = service.NormalizeGradeCode(grade)
+		filter.Grade = &grade
+	}
 	return u.priceRepo.Find(ctx, filter)
 }
 
-// Получить текущее значение счетчика
-func (u *UseCase) GetCurrent(ctx context.Context) (entity.CounterState, error) {
-	return u.counterRepo.GetCurrent(ctx)
+// Получить цену марки, действовавшую в момент t
+func (u *UseCase) GetPriceAt(ctx context.Context, grade string, t time.Time) (entity.FuelPrice, error) {
+	return u.priceService.GetPriceAt(ctx, grade, t)
+}
+
+// Получить цены с периодами действия за промежуток (пустой grade — все марки)
+func (u *UseCase) GetPriceIntervals(ctx context.Context, from, to time.Time, grade string) ([]entity.PriceInterval, error) {
+	return u.priceService.GetPriceIntervals(ctx, grade, from, to)
+}
+
+// Запланировать цену за литр марки с момента effectiveFrom
+func (u *UseCase) SchedulePrice(ctx context.Context, grade string, newPrice entity.Money, effectiveFrom time.Time) (entity.ScheduledPrice, error) {
+	return u.scheduleService.Schedule(ctx, grade, newPrice, effectiveFrom)
+}
+
+// Получить запланированные цены (пустые grade и status — без фильтра)
+func (u *UseCase) GetScheduledPrices(ctx context.Context, grade, status string) ([]entity.ScheduledPrice, error) {
+	return u.scheduleService.List(ctx, grade, status)
+}
+
+// Отменить запланированную цену
+func (u *UseCase) CancelScheduledPrice(ctx context.Context, id string) (entity.ScheduledPrice, error) {
+	return u.scheduleService.Cancel(ctx, id)
 }
 
-// Смена значения счетчика перед заправкой (с проверкой на большесть значения)
-func (u *UseCase) UpdateCounterDuringRefuel(ctx context.Context, val int) (entity.CounterState, error) {
-	return u.counterService.UpdateCounterDuringRefuel(ctx, val)
+// Получить все марки топлива
+func (u *UseCase) GetFuelGrades(ctx context.Context) ([]entity.FuelGrade, error) {
+	return u.gradeService.List(ctx)
 }
 
-// Смена значения счетчика без валидации
-func (u *UseCase) UpdateCounter(ctx context.Context, val int) (entity.CounterState, error) {
-	return u.counterService.UpdateCounter(ctx, val)
+// Добавить марку топлива
+func (u *UseCase) CreateFuelGrade(ctx context.Context, code, name string) (entity.FuelGrade, error) {
+	return u.gradeService.Create(ctx, code, name)
+}
+
+// Получить текущее значение счетчика колонки
+func (u *UseCase) GetCurrent(ctx context.Context, deviceID string) (entity.CounterState, error) {
+	return u.counterRepo.GetCurrent(ctx, deviceID)
+}
+
+// Смена значения счетчика колонки перед заправкой (с проверкой на большесть значения)
+func (u *UseCase) UpdateCounterDuringRefuel(ctx context.Context, deviceID string, val int) (entity.CounterState, error) {
+	return u.counterService.UpdateCounterDuringRefuel(ctx, deviceID, val)
+}
+
+// Смена значения счетчика колонки без валидации
+func (u *UseCase) UpdateCounter(ctx context.Context, deviceID string, val int) (entity.CounterState, error) {
+	return u.counterService.UpdateCounter(ctx, deviceID, val)
+}
+
+// Получить все колонки
+func (u *UseCase) GetDevices(ctx context.Context) ([]entity.Device, error) {
+	return u.deviceService.List(ctx)
+}
+
+// Получить колонку по id
+func (u *UseCase) GetDevice(ctx context.Context, id string) (entity.Device, error) {
+	return u.deviceService.Get(ctx, id)
+}
+
+// Зарегистрировать колонку
+func (u *UseCase) CreateDevice(ctx context.Context, id, name string) (entity.Device, error) {
+	return u.deviceService.Create(ctx, id, name)
+}
+
+// Включить/выключить колонку
+func (u *UseCase) SetDeviceActive(ctx context.Context, id string, active bool) (entity.Device, error) {
+	return u.deviceService.SetActive(ctx, id, active)
 }
 
 // Получить среднюю цену за литр за промежуток
-func (u *UseCase) GetAveragePricePerLiter(ctx context.Context, from, to time.Time) (float64, error) {
+func (u *UseCase) GetAveragePricePerLiter(ctx context.Context, from, to time.Time) (entity.Money, error) {
 	return u.refuelService.GetAveragePricePerLiter(ctx, from, to)
 }
+
+// Получить действующие лимиты цены и оплаты
+func (u *UseCase) GetLimits(ctx context.Context) (entity.Limits, error) {
+	return u.limits.Current(ctx)
+}
+
+// Изменить лимиты цены и оплаты (для администратора)
+func (u *UseCase) UpdateLimits(ctx context.Context, limits entity.Limits) (entity.Limits, error) {
+	return u.limits.Update(ctx, limits)
+}
@@ -1,30 +1,117 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
+	"fuelStation/internal/adapter/grpcserver"
 	"fuelStation/internal/adapter/repository"
+	"fuelStation/internal/adapter/rest"
+	"fuelStation/internal/app"
+	"fuelStation/internal/config"
+	"fuelStation/internal/domain/service"
 	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"google.golang.org/grpc"
 )
 
 func main() {
+	configPath := flag.String("config", "", "путь к файлу конфигурации (по умолчанию $FUELSTATION_CONFIG или config.yaml)")
+	flag.Parse()
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		log.Fatal("❌", err)
+	}
+
+	// Управление миграциями: fuelStation migrate up|down N|status|goto VERSION
+	if flag.NArg() > 0 && flag.Arg(0) == "migrate" {
+		if err := runMigrate(cfg, flag.Args()[1:]); err != nil {
+			log.Fatal("❌", err)
+		}
+		return
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	// Подключение
-	db, err := repository.InitDatabase(repository.DatabaseConfig{
-		Host:     "localhost",
-		Port:     5432,
-		User:     "postgres",
-		Password: "123qwe",
-		DBName:   "gasStation",
-		SSLMode:  "disable",
-	}, "internal/adapter/repository/migrations")
+	db, err := repository.InitDatabase(cfg.RepositoryConfig(), cfg.MigrationsFS())
 	if err != nil {
 		log.Fatal("❌", err)
 	}
 	defer repository.CloseDB(db)
 
-	// Проверка
-	err = db.Ping()
+	station := app.New(db, cfg.ServiceLimits(), cfg.Refuels.PendingTTL, cfg.Refuels.SweepInterval)
+	uc := station.UseCase
+
+	// Фоновая очистка логов
+	go service.NewLogRetentionWorker(station.LogRepo, cfg.Logs.KeepDays, cfg.Logs.RetentionInterval).Run(ctx)
+
+	// Применение запланированных цен
+	go service.NewPriceActivator(station.ScheduleService, cfg.Prices.ScheduleInterval).Run(ctx)
+
+	// Автоотмена зависших операций
+	go station.RefuelSweeper.Run(ctx)
+
+	// HTTP API
+	server := &http.Server{
+		Addr:              cfg.Server.HTTPAddr,
+		Handler:           rest.NewHandler(uc, cfg.Server.AdminToken).Routes(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		log.Printf("✅ HTTP API слушает %s\n", cfg.Server.HTTPAddr)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("❌ HTTP сервер: %v\n", err)
+			stop()
+		}
+	}()
+
+	// gRPC API для терминалов колонок
+	grpcServer := grpc.NewServer()
+	grpcserver.NewServer(uc).Register(grpcServer)
+
+	listener, err := net.Listen("tcp", cfg.Server.GRPCAddr)
 	if err != nil {
 		log.Fatal("❌", err)
 	}
 
-	log.Println("✅ Database OK!")
+	go func() {
+		log.Printf("✅ gRPC API слушает %s\n", cfg.Server.GRPCAddr)
+		if err := grpcServer.Serve(listener); err != nil {
+			log.Printf("❌ gRPC сервер: %v\n", err)
+			stop()
+		}
+	}()
+
+	<-ctx.Done()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Printf("⚠️ Ошибка остановки HTTP сервера: %v\n", err)
+	}
+
+	// Потоки в режиме watch сами не завершаются, поэтому ждём не дольше таймаута
+	grpcStopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(grpcStopped)
+	}()
+	select {
+	case <-grpcStopped:
+	case <-shutdownCtx.Done():
+		grpcServer.Stop()
+	}
+
+	log.Println("✅ Сервер остановлен")
 }
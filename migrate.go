@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"fuelStation/internal/adapter/repository"
+	"fuelStation/internal/config"
+	"os"
+	"strconv"
+	"text/tabwriter"
+)
+
+const migrateUsage = "использование: migrate up | down N | status | goto VERSION"
+
+// Подкоманда migrate
+func runMigrate(cfg config.Config, args []string) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+
+	db, err := repository.OpenDatabase(cfg.RepositoryConfig())
+	if err != nil {
+		return err
+	}
+	defer repository.CloseDB(db)
+
+	migrator, err := repository.NewMigrator(db, cfg.MigrationsFS())
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	switch args[0] {
+	case "up":
+		return migrator.Up(ctx)
+
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps < 1 {
+				return fmt.Errorf("некорректное число шагов %q", args[1])
+			}
+		}
+		return migrator.Down(ctx, steps)
+
+	case "goto":
+		if len(args) < 2 {
+			return errors.New(migrateUsage)
+		}
+		version, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil || version < 0 {
+			return fmt.Errorf("некорректная версия %q", args[1])
+		}
+		return migrator.Goto(ctx, version)
+
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+		for _, st := range statuses {
+			appliedAt := "-"
+			if st.Applied {
+				appliedAt = st.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\n", st.Version, st.Name, appliedAt)
+		}
+		return w.Flush()
+
+	default:
+		return errors.New(migrateUsage)
+	}
+}